@@ -6,6 +6,7 @@ import (
 	"grpc2mcp/internal/examplemcp"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -19,14 +20,25 @@ var exampleMCPCmd = &cobra.Command{
 
 var exampleMCPName string
 var exampleMCPPort int
+var exampleMCPStdio bool
 
 func init() {
 	rootCmd.AddCommand(exampleMCPCmd)
 	exampleMCPCmd.Flags().StringVarP(&exampleMCPName, "name", "n", "trivy", "The name of the server")
 	exampleMCPCmd.Flags().IntVarP(&exampleMCPPort, "port", "p", 8888, "The port to listen on")
+	exampleMCPCmd.Flags().BoolVar(&exampleMCPStdio, "stdio", false, "Serve over stdin/stdout instead of http")
 }
 
 func runExampleMCPServer(cmd *cobra.Command, args []string) {
+	if exampleMCPStdio {
+		// stdout belongs to the protocol here, logging stays on stderr
+		log.Printf("Example MCP server '%s' serving on stdio", exampleMCPName)
+		if err := examplemcp.RunExampleStdioServer(cmd.Context(), exampleMCPName, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("stdio server failed: %v", err)
+		}
+		return
+	}
+
 	handler := examplemcp.RunExampleMcpServer(exampleMCPName, "/mcp")
 
 	addr := fmt.Sprintf(":%d", exampleMCPPort)
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var genProtoCmd = &cobra.Command{
+	Use:   "gen-proto [flags] [-- mcp-command args]",
+	Short: "Generates a .proto with a typed RPC per tool of an MCP server",
+	RunE:  doGenProto,
+}
+
+var (
+	genProtoGoPackage string
+	genProtoOut       string
+)
+
+func doGenProto(cmd *cobra.Command, args []string) error {
+
+	opts, err := toolsProtoOptions()
+	if err != nil {
+		return err
+	}
+	opts.GoPackage = genProtoGoPackage
+
+	s, err := newBackendServer(args)
+	if err != nil {
+		return fmt.Errorf("failed to create proxy server: %w", err)
+	}
+	defer s.Close()
+	file, err := s.DescribeTools(cmd.Context(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to describe tools: %w", err)
+	}
+
+	if genProtoOut == "" {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), file.Format())
+		return err
+	}
+	if err := os.WriteFile(genProtoOut, []byte(file.Format()), 0o644); err != nil {
+		return err
+	}
+	log.Printf("wrote %d tools to %s", len(file.ToolNames), genProtoOut)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(genProtoCmd)
+	genProtoCmd.Flags().StringVar(&mcpUrl, "mcp-url", "http://localhost:8888/mcp/", "The http/https URL of the MCP server")
+	genProtoCmd.Flags().StringVar(&mcpCommand, "mcp-command", "", "Run this stdio MCP server as a child process instead of using --mcp-url, its args follow --")
+	genProtoCmd.Flags().StringVar(&genProtoGoPackage, "go-package", "", "The go_package option of the generated file, if any")
+	genProtoCmd.Flags().StringVarP(&genProtoOut, "out", "o", "", "The file to write, stdout if not set")
+	addToolsProtoFlags(genProtoCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"grpc2mcp/internal/examplemcp"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenProtoCommand(t *testing.T) {
+
+	ts := httptest.NewServer(examplemcp.RunExampleMcpServer(t.Name(), "/mcp"))
+	defer ts.Close()
+
+	defaultMcpUrl := mcpUrl
+	t.Cleanup(func() { mcpUrl = defaultMcpUrl })
+
+	rootCmd.SetContext(t.Context())
+	rootCmd.SetArgs([]string{"gen-proto", "--mcp-url=" + ts.URL, "--go-package=example.com/tools"})
+	out, err := CommandRunner(rootCmd)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "package mcp.tools;")
+	assert.Contains(t, out, `option go_package = "example.com/tools";`)
+	assert.Contains(t, out, "rpc Add(AddRequest) returns (mcp.CallToolResult);")
+	assert.Contains(t, out, "rpc WordCount(WordCountRequest) returns (WordCountResponse);")
+}
@@ -1,31 +1,165 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"grpc2mcp/internal/proxy"
+	"grpc2mcp/internal/toolproto"
 	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	mcpUrl string
-	port   int
+	mcpUrl       string
+	mcpCommand   string
+	port         int
+	typedTools   bool
+	manageSess   bool
+	sessMaxIdle  time.Duration
+	poolSize     int
+	poolMaxSize  int
+	poolMaxIdle  time.Duration
+	poolInterval time.Duration
+	toolsPackage string
+	toolsService string
+	fieldNumbers []string
+
+	backends       []string
+	defaultBackend string
+	aggregate      bool
+	maxListPages   int
+	elicitTimeout  time.Duration
+	maxResumes     int
 )
 
 var proxyCmd = &cobra.Command{
-	Use:   "proxy",
+	Use:   "proxy [flags] [-- mcp-command args]",
 	Short: "Starts the gRPC to MCP proxy",
 	RunE:  doProxy,
 }
 
-func doProxy(cmd *cobra.Command, args []string) error {
+// newBackendServer makes a proxy server for the MCP server given by --mcp-command and
+// its args, or else --mcp-url
+func newBackendServer(args []string) (*proxy.Server, error) {
+	if mcpCommand != "" {
+		log.Printf("using stdio command %s %v as the MCP server\n", mcpCommand, args)
+		return proxy.NewStdioServer(mcpCommand, args...)
+	}
+	log.Printf("using %s as the MCP server\n", mcpUrl)
+	return proxy.NewServer(mcpUrl)
+}
 
-	log.Printf("starting proxy to %s on port %d\n", mcpUrl, port)
-	s, err := proxy.NewServer(mcpUrl)
+// configureBackend applies the session and typed tools flags to s
+func configureBackend(cmd *cobra.Command, s *proxy.Server) error {
+	// aggregating needs sessions the proxy holds, so there has to be one or the other
+	if manageSess || (aggregate && poolSize == 0) {
+		s.ManageSessions(sessMaxIdle)
+	}
+	s.LimitListPages(maxListPages)
+	s.TimeoutElicitations(elicitTimeout)
+	s.ResumeStreams(maxResumes)
+	if poolSize > 0 {
+		s.PoolSessions(proxy.PoolOptions{MinSize: poolSize, MaxSize: poolMaxSize, MaxIdle: poolMaxIdle, HealthCheckInterval: poolInterval})
+	}
+
+	if typedTools {
+		opts, err := toolsProtoOptions()
+		if err != nil {
+			return err
+		}
+		file, err := s.ServeTypedTools(cmd.Context(), opts)
+		if err != nil {
+			return fmt.Errorf("failed to generate typed tools service: %w", err)
+		}
+		log.Printf("serving %d tools as %s.%s", len(file.ToolNames), toolsPackage, toolsService)
+	}
+	return nil
+}
+
+// newRouter makes a router over the MCP servers given by --backend
+func newRouter(cmd *cobra.Command) (*proxy.Router, error) {
+	if typedTools {
+		return nil, fmt.Errorf("--typed-tools can't be used with --backend")
+	}
+
+	servers := map[string]*proxy.Server{}
+	defaultName := defaultBackend
+	for _, backend := range backends {
+		name, url, ok := strings.Cut(backend, "=")
+		if !ok {
+			closeAll(servers)
+			return nil, fmt.Errorf("--backend %s is not name=url", backend)
+		}
+		if _, ok := servers[name]; ok {
+			closeAll(servers)
+			return nil, fmt.Errorf("--backend %s given more than once", name)
+		}
+		s, err := proxy.NewServer(url)
+		if err != nil {
+			closeAll(servers)
+			return nil, err
+		}
+		servers[name] = s
+		if err := configureBackend(cmd, s); err != nil {
+			closeAll(servers)
+			return nil, err
+		}
+		log.Printf("using %s as the MCP server for backend %s\n", url, name)
+		if defaultName == "" {
+			defaultName = name
+		}
+	}
+	r, err := proxy.NewRouter(servers, defaultName)
 	if err != nil {
-		return fmt.Errorf("failed to create proxy server: %w", err)
+		closeAll(servers)
+		return nil, err
+	}
+	if aggregate {
+		r.Aggregate()
 	}
+	r.LimitListPages(maxListPages)
+	return r, nil
+}
+
+func closeAll(servers map[string]*proxy.Server) {
+	for _, s := range servers {
+		s.Close()
+	}
+}
+
+func doProxy(cmd *cobra.Command, args []string) error {
+
+	log.Printf("starting proxy on port %d\n", port)
+	var s interface {
+		StartAsync(port int) (*net.TCPAddr, context.CancelFunc, error)
+		Close() error
+	}
+	if aggregate && len(backends) == 0 {
+		return fmt.Errorf("--aggregate needs --backend")
+	}
+	if len(backends) > 0 {
+		r, err := newRouter(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to create proxy router: %w", err)
+		}
+		s = r
+	} else {
+		backend, err := newBackendServer(args)
+		if err != nil {
+			return fmt.Errorf("failed to create proxy server: %w", err)
+		}
+		s = backend
+		if err := configureBackend(cmd, backend); err != nil {
+			backend.Close()
+			return err
+		}
+	}
+	defer s.Close()
 
 	lisAddr, shutdownFunc, err := s.StartAsync(port)
 	defer shutdownFunc()
@@ -45,5 +179,47 @@ func doProxy(cmd *cobra.Command, args []string) error {
 func init() {
 	rootCmd.AddCommand(proxyCmd)
 	proxyCmd.Flags().StringVar(&mcpUrl, "mcp-url", "http://localhost:8888/mcp/", "The http/https URL of the MCP server")
+	proxyCmd.Flags().StringVar(&mcpCommand, "mcp-command", "", "Run this stdio MCP server as a child process instead of using --mcp-url, its args follow --")
 	proxyCmd.Flags().IntVar(&port, "port", 8080, "The port for the proxy to listen on")
+	proxyCmd.Flags().BoolVar(&typedTools, "typed-tools", false, "Also serve the MCP server's tools as a typed service, as generated by gen-proto")
+	proxyCmd.Flags().BoolVar(&manageSess, "manage-sessions", false, "Open MCP sessions for callers that don't send an mcp-session-id, keyed by their authorization header")
+	proxyCmd.Flags().DurationVar(&sessMaxIdle, "session-max-idle", proxy.DefaultManagedSessionMaxIdle, "End managed sessions no caller has used for this long, 0 for no limit")
+	proxyCmd.Flags().IntVar(&poolSize, "pool-size", 0, "Keep this many MCP sessions warm for anonymous callers that don't send an mcp-session-id, 0 for no pool")
+	proxyCmd.Flags().IntVar(&poolMaxSize, "pool-max-size", 0, "The most idle sessions the pool keeps after a burst of calls, ending the rest, 0 for no limit")
+	proxyCmd.Flags().DurationVar(&poolMaxIdle, "pool-max-idle", 5*time.Minute, "End pooled sessions unused for this long, 0 for no limit")
+	proxyCmd.Flags().DurationVar(&poolInterval, "pool-health-interval", 30*time.Second, "How often pooled sessions are pinged")
+	proxyCmd.Flags().StringArrayVar(&backends, "backend", nil, "A name=url MCP server to route to, can be repeated. Calls pick one with the x-mcp-backend header or a name/ prefix on the tool")
+	proxyCmd.Flags().StringVar(&defaultBackend, "default-backend", "", "The --backend for calls that don't pick one, defaults to the first")
+	proxyCmd.Flags().BoolVar(&aggregate, "aggregate", false, "List the tools, prompts and resources of every --backend as one, named backend/name. Implies --manage-sessions without --pool-size")
+	proxyCmd.Flags().IntVar(&maxListPages, "max-list-pages", proxy.DefaultMaxListPages, "The most pages the ListAll RPCs follow before giving up")
+	proxyCmd.Flags().DurationVar(&elicitTimeout, "elicit-timeout", proxy.DefaultElicitTimeout, "How long an MCP server's elicitation waits on the gRPC client before the proxy cancels it")
+	proxyCmd.Flags().IntVar(&maxResumes, "sse-resume-attempts", proxy.DefaultMaxStreamResumes, "How many times a call resumes an SSE response that broke off, with Last-Event-ID, before failing, 0 for never")
+	addToolsProtoFlags(proxyCmd)
+}
+
+// addToolsProtoFlags adds the flags naming the typed tools service
+func addToolsProtoFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&toolsPackage, "tools-package", "mcp.tools", "The proto package of the typed tools service")
+	cmd.Flags().StringVar(&toolsService, "tools-service", "Tools", "The name of the typed tools service")
+	cmd.Flags().StringArrayVar(&fieldNumbers, "field-number", nil, "Pins a typed tools field number as Message.property=number, eg GetIssueRequest.labels=2, can be repeated")
+}
+
+// toolsProtoOptions makes the options of the typed tools service from its flags
+func toolsProtoOptions() (toolproto.Options, error) {
+	opts := toolproto.Options{Package: toolsPackage, Service: toolsService}
+	for _, pin := range fieldNumbers {
+		key, value, ok := strings.Cut(pin, "=")
+		if !ok {
+			return opts, fmt.Errorf("--field-number %s is not Message.property=number", pin)
+		}
+		number, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return opts, fmt.Errorf("--field-number %s is not Message.property=number", pin)
+		}
+		if opts.FieldNumbers == nil {
+			opts.FieldNumbers = map[string]int32{}
+		}
+		opts.FieldNumbers[key] = int32(number)
+	}
+	return opts, nil
 }
@@ -27,3 +27,15 @@ func TestRunningProxyCommand(t *testing.T) {
 	runSubCommand(t, rootCmd, 250*time.Hour, runningCheckStr)
 
 }
+
+func TestProxyCommandBackends(t *testing.T) {
+	// flags live on in package vars, don't leave the backends to later tests
+	t.Cleanup(func() { backends, defaultBackend, aggregate, manageSess = nil, "", false, false })
+
+	rootCmd.SetArgs([]string{"proxy", "--port=0",
+		"--backend=github=http://localhost:8888/mcp/", "--backend=jira=http://localhost:8889/mcp/",
+		"--default-backend=jira", "--aggregate"})
+	runningCheckStr := []string{"backend github", "backend jira", "proxy server listening on"}
+
+	runSubCommand(t, rootCmd, 250*time.Millisecond, runningCheckStr)
+}
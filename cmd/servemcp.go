@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"grpc2mcp/internal/reverse"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var serveMCPCmd = &cobra.Command{
+	Use:   "serve-mcp",
+	Short: "Serves the unary methods of a gRPC service as MCP tools",
+	RunE:  doServeMCP,
+}
+
+var (
+	serveMCPGrpcTarget    string
+	serveMCPDescriptorSet string
+	serveMCPName          string
+	serveMCPPort          int
+	serveMCPUri           string
+)
+
+func init() {
+	rootCmd.AddCommand(serveMCPCmd)
+	serveMCPCmd.Flags().StringVar(&serveMCPGrpcTarget, "grpc-target", "", "The host:port of the gRPC server to expose")
+	serveMCPCmd.Flags().StringVar(&serveMCPDescriptorSet, "descriptor-set", "", "A FileDescriptorSet describing the service, instead of using server reflection")
+	serveMCPCmd.Flags().StringVarP(&serveMCPName, "name", "n", "grpc2mcp", "The name of the MCP server")
+	serveMCPCmd.Flags().IntVarP(&serveMCPPort, "port", "p", 8888, "The port to listen on")
+	serveMCPCmd.Flags().StringVar(&serveMCPUri, "uri", "/mcp", "The path the MCP server is served on")
+	serveMCPCmd.MarkFlagRequired("grpc-target")
+}
+
+func doServeMCP(cmd *cobra.Command, args []string) error {
+
+	conn, err := grpc.NewClient(serveMCPGrpcTarget, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to create grpc client for %s: %w", serveMCPGrpcTarget, err)
+	}
+	defer conn.Close()
+
+	var services []protoreflect.ServiceDescriptor
+	if serveMCPDescriptorSet != "" {
+		services, err = reverse.ServicesFromDescriptorSet(serveMCPDescriptorSet)
+	} else {
+		services, err = reverse.ServicesFromReflection(cmd.Context(), conn)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to describe services of %s: %w", serveMCPGrpcTarget, err)
+	}
+
+	handler, err := reverse.NewHandler(serveMCPName, serveMCPUri, conn, services)
+	if err != nil {
+		return fmt.Errorf("failed to create mcp server: %w", err)
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", serveMCPPort))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: handler}
+	go func() {
+		if err := server.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.Printf("mcp server error: %v", err)
+		}
+	}()
+	for _, svc := range services {
+		log.Printf("found service %s with %d methods", svc.FullName(), svc.Methods().Len())
+	}
+	log.Printf("mcp server for %s listening on %s", serveMCPGrpcTarget, lis.Addr())
+
+	<-cmd.Context().Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestServeMCPCommand(t *testing.T) {
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	reflection.Register(grpcServer)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	rootCmd.SetArgs([]string{"serve-mcp", "--port=0", "--grpc-target=" + lis.Addr().String()})
+	runningCheckStr := []string{"found service grpc.health.v1.Health", "listening on"}
+
+	runSubCommand(t, rootCmd, 250*time.Millisecond, runningCheckStr)
+}
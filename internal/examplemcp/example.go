@@ -2,30 +2,56 @@ package examplemcp
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
-	"net/http"
+	"io"
+	"log"
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 const (
-	PARAM_WHOM = "whom"
-	PARAM_A    = "a"
-	PARAM_B    = "b"
-	PARAM_S    = "s"
+	PARAM_WHOM  = "whom"
+	PARAM_A     = "a"
+	PARAM_B     = "b"
+	PARAM_S     = "s"
+	PARAM_STEPS = "steps"
 
 	TOOL_ADD            = "add"
 	TOOL_MULT           = "mult"
 	TOOL_LOWER          = "lower"
 	TOOL_GREET_RESOURCE = "greetResource"
+	TOOL_SAMPLE_CONTENT = "sampleContent"
+	TOOL_WORD_COUNT     = "wordCount"
+	TOOL_COUNT_STEPS    = "countSteps"
+	TOOL_SUMMARIZE      = "summarize"
+	TOOL_ENABLE_UPPER   = "enableUpper"
 
-	RESOURCE_URI_STATIC = "test://static/resource"
+	// TOOL_UPPER is only listed for a session once it calls TOOL_ENABLE_UPPER
+	TOOL_UPPER = "upper"
+
+	RESOURCE_URI_STATIC      = "test://static/resource"
+	RESOURCE_URI_STATIC_BLOB = "test://static/blob"
+	RESOURCE_TEXT_STATIC     = "This is a sample resource"
+
+	RESOURCE_URI_TEMPLATE_GREETING = "test://greeting/{whom}"
+	resourceUriGreetingPrefix      = "test://greeting/"
 
 	PROMPT_GREET = "greet"
+
+	LOGGER_NAME = "example"
+
+	SUMMARIZE_SYSTEM_PROMPT = "Summarize the user's text in a sentence"
+	SUMMARIZE_MAX_TOKENS    = 100
+
+	SERVER_VERSION      = "0.0.0"
+	SERVER_INSTRUCTIONS = "An example server with some math and string tools"
 )
 
 func GetProvidedToolNames() []string {
@@ -69,8 +95,57 @@ var toolsProvided = []struct {
 			mcp.WithString(PARAM_WHOM, mcp.Required()),
 		), doToolWithResourceLink,
 	},
+	{
+		mcp.NewTool(TOOL_SAMPLE_CONTENT,
+			mcp.WithDescription("returns one of each non-text content type"),
+		), doSampleContent,
+	},
+	{
+		mcp.NewTool(TOOL_WORD_COUNT,
+			mcp.WithDescription("count the words and characters in a string"),
+			mcp.WithString(PARAM_S, mcp.Required()),
+			mcp.WithOutputSchema[WordCount](),
+		), doWordCount,
+	},
+	{
+		mcp.NewTool(TOOL_COUNT_STEPS,
+			mcp.WithDescription("slowly counts up to steps, reporting progress and logging along the way"),
+			mcp.WithNumber(PARAM_STEPS, mcp.Required()),
+		), doCountSteps,
+	},
+	{
+		mcp.NewTool(TOOL_SUMMARIZE,
+			mcp.WithDescription("asks the client's model to summarize a string, the client has to be listening for sampling requests"),
+			mcp.WithString(PARAM_S, mcp.Required()),
+		), doSummarize,
+	},
+	{
+		mcp.NewTool(TOOL_ENABLE_UPPER,
+			mcp.WithDescription("adds an upper tool for this session, the client has to be listening to hear its tools changed"),
+		), doEnableUpper,
+	},
 }
 
+var upperTool = mcp.NewTool(TOOL_UPPER,
+	mcp.WithDescription("upper case a string"),
+	mcp.WithString(PARAM_S, mcp.Required()),
+)
+
+// how long each step of countSteps takes
+const countStepInterval = 20 * time.Millisecond
+
+// WordCount is the structured output of the wordCount tool
+type WordCount struct {
+	Words      int `json:"words"`
+	Characters int `json:"characters"`
+}
+
+// the media the sampleContent tool returns, base64 encoded on the wire
+var (
+	SampleImageData = []byte{0x89, 'P', 'N', 'G'}
+	SampleAudioData = []byte{'R', 'I', 'F', 'F'}
+)
+
 func GetProvidedPrompts() []string {
 
 	names := make([]string, len(promptsProvided))
@@ -97,15 +172,57 @@ var promptsProvided = []struct {
 
 var ResourcesProvided = []mcp.Resource{
 	mcp.NewResource(RESOURCE_URI_STATIC, "Static Resource", mcp.WithMIMEType("text/plain")),
+	mcp.NewResource(RESOURCE_URI_STATIC_BLOB, "Static Resource Blob", mcp.WithMIMEType("application/octet-stream")),
+}
+
+// the blob resource serves these bytes, base64 encoded on the wire
+var ResourceBlobStatic = []byte{0x00, 0x01, 0xfe, 0xff}
+
+var ResourceTemplateGreeting = mcp.NewResourceTemplate(RESOURCE_URI_TEMPLATE_GREETING, "Greeting",
+	mcp.WithTemplateDescription("greets whom"), mcp.WithTemplateMIMEType("text/plain"))
+
+// CompletionNames are what completing the whom argument of the greet prompt or the
+// greeting resource template chooses from
+var CompletionNames = []string{"alice", "bob", "bobby", "carol"}
+
+func RunExampleMcpServer(serverName string, uri string) *ExtensionHandler {
+	s := NewExampleMcpServer(serverName)
+
+	// TODO consider having an optional param for uri that defaults to /mcp
+	httpServer := server.NewStreamableHTTPServer(s, server.WithEndpointPath(uri))
+
+	return newExtensionHandler(s, httpServer)
+}
+
+// RunExampleStdioServer serves the example server over the stdio transport, reading
+// requests from in and writing responses to out until in is closed or ctx is done.
+func RunExampleStdioServer(ctx context.Context, serverName string, in io.Reader, out io.Writer) error {
+	stdioServer := server.NewStdioServer(NewExampleMcpServer(serverName))
+	return stdioServer.Listen(ctx, in, out)
 }
 
-func RunExampleMcpServer(serverName string, uri string) http.Handler {
+// NewExampleMcpServer puts together the example server with all its tools, prompts and
+// resources, ready for whichever transport.
+func NewExampleMcpServer(serverName string) *server.MCPServer {
+	hooks := &server.Hooks{}
 	s := server.NewMCPServer(serverName,
-		"0.0.0",
+		SERVER_VERSION,
+		server.WithInstructions(SERVER_INSTRUCTIONS),
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
 		server.WithPromptCapabilities(true),
+		server.WithLogging(),
+		server.WithHooks(hooks),
 	)
+	hooks.AddOnRegisterSession(func(_ context.Context, session server.ClientSession) {
+		listeningSessions.Store(session.SessionID(), session)
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		listeningSessions.Delete(session.SessionID())
+	})
+	hooks.AddAfterSetLevel(func(ctx context.Context, _ any, _ *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+		logAtEveryLevel(s, server.ClientSessionFromContext(ctx).SessionID())
+	})
 
 	// Add the tools/prompts
 	for _, tp := range toolsProvided {
@@ -119,11 +236,33 @@ func RunExampleMcpServer(serverName string, uri string) http.Handler {
 	for _, rp := range ResourcesProvided {
 		s.AddResource(rp, handleReadResource)
 	}
+	s.AddResourceTemplate(ResourceTemplateGreeting, handleReadGreeting)
 
-	// TODO consider having an optional param for uri that defaults to /mcp
-	httpServer := server.NewStreamableHTTPServer(s, server.WithEndpointPath(uri))
+	return s
+}
 
-	return httpServer
+// listeningSessions are the registered sessions by id. Over streamable http that's the
+// session of a GET stream, the only one mcp-go sends sampling requests on, where the
+// session of the POST calling a tool lasts only as long as the call.
+var listeningSessions sync.Map
+
+// LoggingLevels are the levels logAtEveryLevel logs at, least severe first
+var LoggingLevels = []mcp.LoggingLevel{
+	mcp.LoggingLevelDebug, mcp.LoggingLevelInfo, mcp.LoggingLevelNotice, mcp.LoggingLevelWarning,
+	mcp.LoggingLevelError, mcp.LoggingLevelCritical, mcp.LoggingLevelAlert, mcp.LoggingLevelEmergency,
+}
+
+// logAtEveryLevel gives a session that sets its logging level a message at each level,
+// so it has something to see. Those under its level are dropped by mcp-go, and they
+// go out over the session's GET stream so only reach clients which are listening.
+func logAtEveryLevel(s *server.MCPServer, sessionID string) {
+	for _, level := range LoggingLevels {
+		notification := mcp.NewLoggingMessageNotification(level, LOGGER_NAME, fmt.Sprintf("a %s message", level))
+		if err := s.SendLogMessageToSpecificClient(sessionID, notification); err != nil {
+			log.Printf("failed to log to session %s: %v", sessionID, err)
+			return
+		}
+	}
 }
 
 // below are the handlers for the respective MCP entities
@@ -158,6 +297,121 @@ func doLower(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolRes
 	return mcp.NewToolResultText(strings.ToLower(s)), nil
 }
 
+func doUpper(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	s, err := request.RequireString(PARAM_S)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(strings.ToUpper(s)), nil
+}
+
+// doEnableUpper gives the session its own upper tool. mcp-go tells the session its
+// tools changed over its GET stream, and only lets us add tools while there is one.
+func doEnableUpper(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return mcp.NewToolResultError("no session to add the tool to"), nil
+	}
+	err := server.ServerFromContext(ctx).AddSessionTool(session.SessionID(), upperTool, doUpper)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("%s is enabled", TOOL_UPPER)), nil
+}
+
+func doWordCount(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	s, err := request.RequireString(PARAM_S)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultStructuredOnly(WordCount{
+		Words:      len(strings.Fields(s)),
+		Characters: len(s),
+	}), nil
+}
+
+func doCountSteps(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	steps, err := request.RequireInt(PARAM_STEPS)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var progressToken mcp.ProgressToken
+	if request.Params.Meta != nil {
+		progressToken = request.Params.Meta.ProgressToken
+	}
+
+	mcpServer := server.ServerFromContext(ctx)
+	for step := 1; step <= steps; step++ {
+		err := mcpServer.SendNotificationToClient(ctx, "notifications/message", map[string]any{
+			"level":  mcp.LoggingLevelInfo,
+			"logger": TOOL_COUNT_STEPS,
+			"data":   fmt.Sprintf("step %d", step),
+		})
+		if err != nil {
+			return nil, err
+		}
+		// progress only goes to clients that asked for it
+		if progressToken != nil {
+			err = mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+				"progressToken": progressToken,
+				"progress":      step,
+				"total":         steps,
+				"message":       fmt.Sprintf("step %d of %d", step, steps),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		// mcp-go writes notifications out asynchronously and drops any still queued
+		// once the result is written, so take our time like a real long running tool
+		select {
+		case <-time.After(countStepInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("%d", steps)), nil
+}
+
+func doSummarize(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	s, err := request.RequireString(PARAM_S)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	mcpServer := server.ServerFromContext(ctx)
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		if listening, ok := listeningSessions.Load(session.SessionID()); ok {
+			ctx = mcpServer.WithContext(ctx, listening.(server.ClientSession))
+		}
+	}
+
+	result, err := mcpServer.RequestSampling(ctx, mcp.CreateMessageRequest{
+		CreateMessageParams: mcp.CreateMessageParams{
+			Messages:     []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent(s)}},
+			SystemPrompt: SUMMARIZE_SYSTEM_PROMPT,
+			MaxTokens:    SUMMARIZE_MAX_TOKENS,
+		},
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// the sample comes back as whatever json made of it
+	content, _ := result.Content.(map[string]any)
+	text, ok := content["text"].(string)
+	if !ok {
+		return mcp.NewToolResultError("the client's summary was not text"), nil
+	}
+	return mcp.NewToolResultText(text), nil
+}
+
 func doToolWithResourceLink(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
 	whoParam, err := request.RequireString(PARAM_WHOM)
@@ -179,6 +433,26 @@ func doToolWithResourceLink(_ context.Context, request mcp.CallToolRequest) (*mc
 	}, nil
 }
 
+func doSampleContent(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewImageContent(base64.StdEncoding.EncodeToString(SampleImageData), "image/png"),
+			mcp.NewAudioContent(base64.StdEncoding.EncodeToString(SampleAudioData), "audio/wav"),
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{
+				URI:      RESOURCE_URI_STATIC,
+				MIMEType: "text/plain",
+				Text:     RESOURCE_TEXT_STATIC,
+			}),
+			mcp.NewEmbeddedResource(mcp.BlobResourceContents{
+				URI:      RESOURCE_URI_STATIC_BLOB,
+				MIMEType: "application/octet-stream",
+				Blob:     base64.StdEncoding.EncodeToString(ResourceBlobStatic),
+			}),
+		},
+	}, nil
+}
+
 // TODO get something more idiomatic. this is just a call repsonse
 func doGreetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 
@@ -198,11 +472,34 @@ func doGreetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetP
 }
 
 func handleReadResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+
+	if request.Params.URI == RESOURCE_URI_STATIC_BLOB {
+		return []mcp.ResourceContents{
+			mcp.BlobResourceContents{
+				URI:      RESOURCE_URI_STATIC_BLOB,
+				MIMEType: "application/octet-stream",
+				Blob:     base64.StdEncoding.EncodeToString(ResourceBlobStatic),
+			},
+		}, nil
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      RESOURCE_URI_STATIC,
+			MIMEType: "text/plain",
+			Text:     RESOURCE_TEXT_STATIC,
+		},
+	}, nil
+}
+
+func handleReadGreeting(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+
+	whom := strings.TrimPrefix(request.Params.URI, resourceUriGreetingPrefix)
 	return []mcp.ResourceContents{
 		mcp.TextResourceContents{
-			URI:      "test://static/resource",
+			URI:      request.Params.URI,
 			MIMEType: "text/plain",
-			Text:     "This is a sample resource",
+			Text:     fmt.Sprintf("Hello, %s!", whom),
 		},
 	}, nil
 }
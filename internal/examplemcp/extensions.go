@@ -0,0 +1,219 @@
+package examplemcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// JSON-RPC methods mcp-go has no handlers for
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+	methodCompletionComplete   = "completion/complete"
+)
+
+// extensionMethod answers one JSON-RPC request for the session that made it.
+type extensionMethod func(sessionID string, params json.RawMessage) (any, error)
+
+// ExtensionHandler sits in front of the mcp-go streamable http server and answers
+// the JSON-RPC methods mcp-go doesn't implement yet, so the proxy has something real
+// to test against. It also turns away sessions that were ended with a DELETE, which
+// mcp-go goes on accepting. Everything else is passed through untouched.
+type ExtensionHandler struct {
+	mcpServer *server.MCPServer
+	next      http.Handler
+	methods   map[string]extensionMethod
+
+	mu            sync.Mutex
+	subscriptions map[string]map[string]bool // session id -> subscribed uris
+	terminated    map[string]bool            // session ids ended with a DELETE
+}
+
+func newExtensionHandler(mcpServer *server.MCPServer, next http.Handler) *ExtensionHandler {
+	eh := &ExtensionHandler{
+		mcpServer:     mcpServer,
+		next:          next,
+		subscriptions: map[string]map[string]bool{},
+		terminated:    map[string]bool{},
+	}
+	eh.methods = map[string]extensionMethod{
+		methodResourcesSubscribe:   eh.doSubscribe,
+		methodResourcesUnsubscribe: eh.doUnsubscribe,
+		methodCompletionComplete:   eh.doComplete,
+	}
+	return eh
+}
+
+func (eh *ExtensionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(server.HeaderKeySessionID)
+	eh.mu.Lock()
+	terminated := eh.terminated[sessionID]
+	eh.mu.Unlock()
+	if terminated {
+		http.Error(w, "Session terminated", http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodDelete && sessionID != "" {
+		eh.mu.Lock()
+		eh.terminated[sessionID] = true
+		delete(eh.subscriptions, sessionID)
+		eh.mu.Unlock()
+	}
+	if r.Method != http.MethodPost {
+		eh.next.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	_ = json.Unmarshal(body, &request) // let mcp-go report anything malformed
+
+	method, ok := eh.methods[request.Method]
+	if !ok || request.ID == nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		eh.next.ServeHTTP(w, r)
+		return
+	}
+
+	result, err := method(sessionID, request.Params)
+
+	// echo the id back raw so it round trips exactly
+	response := map[string]any{"jsonrpc": mcp.JSONRPC_VERSION, "id": request.ID}
+	if err != nil {
+		response["error"] = map[string]any{"code": mcp.INVALID_PARAMS, "message": err.Error()}
+	} else {
+		response["result"] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("failed to write %s response: %v", request.Method, err)
+	}
+}
+
+func (eh *ExtensionHandler) doSubscribe(sessionID string, params json.RawMessage) (any, error) {
+	var subscribeParams mcp.SubscribeParams
+	if err := json.Unmarshal(params, &subscribeParams); err != nil {
+		return nil, err
+	}
+
+	eh.mu.Lock()
+	if eh.subscriptions[sessionID] == nil {
+		eh.subscriptions[sessionID] = map[string]bool{}
+	}
+	eh.subscriptions[sessionID][subscribeParams.URI] = true
+	eh.mu.Unlock()
+
+	// our resources never change, so acknowledge the subscription with an update
+	// straight away to give subscribers something to see
+	eh.notifyResourceUpdated(sessionID, subscribeParams.URI)
+
+	return mcp.EmptyResult{}, nil
+}
+
+// UpdateResource tells the sessions subscribed to uri that it has changed, as a
+// resource that does change would.
+func (eh *ExtensionHandler) UpdateResource(uri string) {
+	eh.mu.Lock()
+	var subscribers []string
+	for sessionID, uris := range eh.subscriptions {
+		if uris[uri] {
+			subscribers = append(subscribers, sessionID)
+		}
+	}
+	eh.mu.Unlock()
+
+	for _, sessionID := range subscribers {
+		eh.notifyResourceUpdated(sessionID, uri)
+	}
+}
+
+// Subscribed is whether sessionID is subscribed to uri, so tests can see a
+// resources/unsubscribe arrive.
+func (eh *ExtensionHandler) Subscribed(sessionID, uri string) bool {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	return eh.subscriptions[sessionID][uri]
+}
+
+func (eh *ExtensionHandler) doUnsubscribe(sessionID string, params json.RawMessage) (any, error) {
+	var unsubscribeParams mcp.UnsubscribeParams
+	if err := json.Unmarshal(params, &unsubscribeParams); err != nil {
+		return nil, err
+	}
+
+	eh.mu.Lock()
+	delete(eh.subscriptions[sessionID], unsubscribeParams.URI)
+	eh.mu.Unlock()
+
+	return mcp.EmptyResult{}, nil
+}
+
+// doComplete completes the whom argument of the greet prompt and of the greeting
+// resource template, from CompletionNames.
+func (eh *ExtensionHandler) doComplete(_ string, params json.RawMessage) (any, error) {
+	var completeParams struct {
+		Ref struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+			URI  string `json:"uri"`
+		} `json:"ref"`
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+	}
+	if err := json.Unmarshal(params, &completeParams); err != nil {
+		return nil, err
+	}
+
+	ref := completeParams.Ref
+	switch {
+	case ref.Type == "ref/prompt" && ref.Name == PROMPT_GREET:
+	case ref.Type == "ref/resource" && ref.URI == RESOURCE_URI_TEMPLATE_GREETING:
+	default:
+		return nil, fmt.Errorf("nothing to complete for %s %s%s", ref.Type, ref.Name, ref.URI)
+	}
+	if completeParams.Argument.Name != PARAM_WHOM {
+		return nil, fmt.Errorf("no completions for argument %s", completeParams.Argument.Name)
+	}
+
+	var result mcp.CompleteResult
+	result.Completion.Values = []string{}
+	for _, name := range CompletionNames {
+		if strings.HasPrefix(name, completeParams.Argument.Value) {
+			result.Completion.Values = append(result.Completion.Values, name)
+		}
+	}
+	result.Completion.Total = len(result.Completion.Values)
+	return result, nil
+}
+
+// notifyResourceUpdated goes out over the session's GET stream, so it only reaches
+// clients which are listening.
+func (eh *ExtensionHandler) notifyResourceUpdated(sessionID string, uri string) {
+	params := map[string]any{"uri": uri}
+	err := eh.mcpServer.SendNotificationToSpecificClient(sessionID, string(mcp.MethodNotificationResourceUpdated), params)
+	if err != nil {
+		log.Printf("failed to notify session %s of update to %s: %v", sessionID, uri, err)
+	}
+}
@@ -0,0 +1,170 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"grpc2mcp/internal/mcpconst"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewJSONRPCBatchRequest creates the POST carrying a jsonRpcMethod request for each of
+// params as one JSON-RPC batch, and returns the ids it gave them, in order. Only MCP
+// 2025-03-26 has batches, servers speaking any other revision turn them away.
+func NewJSONRPCBatchRequest(ctx context.Context, url string, jsonRpcMethod mcpconst.JsonRpcMethod, params []any,
+	additionalHeaders map[string]string, reqFunc NewHttpRequester) (*http.Request, []jsonrpc2.ID, error) {
+
+	batch := make([]*jsonrpc2.Request, len(params))
+	ids := make([]jsonrpc2.ID, len(params))
+	taken := map[jsonrpc2.ID]bool{}
+	for i, p := range params {
+		paramsMsg, err := json.Marshal(p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal params: %w", err)
+		}
+
+		// the ids are all that tells the responses apart
+		id := newID()
+		for taken[id] {
+			id = newID()
+		}
+		taken[id] = true
+
+		ids[i] = id
+		batch[i] = &jsonrpc2.Request{Method: string(jsonRpcMethod), Params: (*json.RawMessage)(&paramsMsg), ID: id}
+	}
+
+	bodyBytes, err := json.Marshal(batch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error putting together jsonrpc batch: %w", err)
+	}
+
+	req, err := reqFunc(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem creating new JSONRPC batch: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	for header, val := range additionalHeaders {
+		req.Header.Set(header, val)
+	}
+
+	return req, ids, nil
+}
+
+// DoBatchRequest sends a batch and returns the responses to ids that came back, by id.
+// The server may answer with a JSON array or on an SSE stream, where its own requests
+// and notifications are handed to onMessage. The stream is read until every id has its
+// response or the server ends it, so a response can be missing without an error. A
+// batch the server turned away as a whole, with a lone JSON-RPC error or a 400 or 415
+// status as servers without batching do whatever revision they negotiated, is an
+// Unimplemented error. Any other failing status is reported as for a single request.
+func DoBatchRequest(ctx context.Context, client *http.Client, req *http.Request, ids []jsonrpc2.ID,
+	onMessage func(*jsonrpc2.Request) error) (map[jsonrpc2.ID]*jsonrpc2.Response, error) {
+
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to call mcp server: %v", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusBadRequest || httpResp.StatusCode == http.StatusUnsupportedMediaType {
+		body, _ := io.ReadAll(httpResp.Body)
+		return nil, status.Errorf(codes.Unimplemented, "mcp server turned the batch away: %d: %s", httpResp.StatusCode, string(body))
+	}
+	if err := statusError(httpResp); err != nil {
+		return nil, err
+	}
+
+	wanted := map[jsonrpc2.ID]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	responses := map[jsonrpc2.ID]*jsonrpc2.Response{}
+
+	if contentType := httpResp.Header.Get("Content-Type"); strings.Contains(contentType, "text/event-stream") {
+		events := NewEventReader(httpResp.Body)
+		for len(responses) < len(wanted) {
+			event, err := events.Next()
+			if err == io.EOF {
+				return responses, nil
+			}
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to read mcp server SSE response: %v", err)
+			}
+			if event.Type != "message" {
+				continue
+			}
+
+			// an event can carry a batch of its own
+			for _, data := range splitBatch(event.Data) {
+				msg, resp, err := decodeMessage(data)
+				if err != nil {
+					return nil, err
+				}
+				if resp != nil {
+					if wanted[resp.ID] {
+						responses[resp.ID] = resp
+					}
+					continue
+				}
+				if onMessage == nil {
+					continue
+				}
+				if err := onMessage(msg); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return responses, nil
+	}
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read mcp server response: %v", err)
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(respBody, &batch); err != nil {
+		// a lone response to a batch is the server refusing all of it
+		var resp jsonrpc2.Response
+		if err := json.Unmarshal(respBody, &resp); err == nil && resp.Error != nil {
+			return nil, status.Errorf(codes.Unimplemented, "mcp server turned the batch away (code %d): %s",
+				resp.Error.Code, resp.Error.Message)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal mcp server batch response: %s", string(respBody))
+	}
+	for _, data := range batch {
+		var resp jsonrpc2.Response
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal mcp server response: %s", string(data))
+		}
+		if wanted[resp.ID] {
+			responses[resp.ID] = &resp
+		}
+	}
+	return responses, nil
+}
+
+// splitBatch returns the messages of a JSON-RPC batch, or data itself if it's just the
+// one message.
+func splitBatch(data []byte) [][]byte {
+	var batch []json.RawMessage
+	if len(bytes.TrimSpace(data)) == 0 || bytes.TrimSpace(data)[0] != '[' || json.Unmarshal(data, &batch) != nil {
+		return [][]byte{data}
+	}
+	messages := make([][]byte, len(batch))
+	for i, msg := range batch {
+		messages[i] = msg
+	}
+	return messages
+}
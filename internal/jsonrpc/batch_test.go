@@ -0,0 +1,111 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"grpc2mcp/internal/mcpconst"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDoBatchRequest(t *testing.T) {
+
+	params := []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}, map[string]any{"name": "c"}}
+
+	// the server answers out of order, some responses batched in an event of their own
+	// and some alone, with a notification and a response to some other request between
+	sse := func(w http.ResponseWriter, batch []jsonrpc2.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"c\"}\n\n", batch[2].ID)
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\",\"params\":{}}\n\n"))
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":\"other\",\"result\":\"other\"}\n\n"))
+		_, _ = fmt.Fprintf(w, "data: [{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"b\"},{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"a\"}]\n\n",
+			batch[1].ID, batch[0].ID)
+	}
+	plain := func(w http.ResponseWriter, batch []jsonrpc2.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, "[{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"b\"},{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"c\"},"+
+			"{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"a\"}]", batch[1].ID, batch[2].ID, batch[0].ID)
+	}
+
+	for name, respond := range map[string]func(http.ResponseWriter, []jsonrpc2.Request){"sse": sse, "json": plain} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var batch []jsonrpc2.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+				require.Len(t, batch, 3)
+				respond(w, batch)
+			}))
+			defer server.Close()
+
+			req, ids, err := NewJSONRPCBatchRequest(context.Background(), server.URL, mcpconst.ToolsCall, params, nil, http.NewRequestWithContext)
+			require.NoError(t, err)
+			require.Len(t, ids, 3)
+
+			var notified []string
+			responses, err := DoBatchRequest(context.Background(), server.Client(), req, ids, func(msg *jsonrpc2.Request) error {
+				notified = append(notified, msg.Method)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, responses, 3)
+			for i, want := range []string{"a", "b", "c"} {
+				var result string
+				require.NoError(t, json.Unmarshal(*responses[ids[i]].Result, &result))
+				assert.Equal(t, want, result)
+			}
+			if name == "sse" {
+				assert.Equal(t, []string{"notifications/message"}, notified)
+			}
+		})
+	}
+}
+
+func TestDoBatchRequest_Refused(t *testing.T) {
+	// servers without batching answer the whole batch with one error, either as a
+	// response of its own or with a client error status
+	for _, statusCode := range []int{http.StatusOK, http.StatusBadRequest, http.StatusUnsupportedMediaType} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batches not supported"}}`))
+		}))
+		defer server.Close()
+
+		req, ids, err := NewJSONRPCBatchRequest(context.Background(), server.URL, mcpconst.ToolsCall, []any{nil}, nil, http.NewRequestWithContext)
+		require.NoError(t, err)
+
+		responses, err := DoBatchRequest(context.Background(), server.Client(), req, ids, nil)
+		assert.Nil(t, responses)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+		assert.ErrorContains(t, err, "batches not supported")
+	}
+}
+
+func TestDoBatchRequest_FailingStatus(t *testing.T) {
+	// a batch that didn't get through isn't one the server can't take, so the caller
+	// sees the same error a single request would get
+	for _, statusCode := range []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statusCode)
+		}))
+		defer server.Close()
+
+		req, ids, err := NewJSONRPCBatchRequest(context.Background(), server.URL, mcpconst.ToolsCall, []any{nil}, nil, http.NewRequestWithContext)
+		require.NoError(t, err)
+
+		responses, err := DoBatchRequest(context.Background(), server.Client(), req, ids, nil)
+		assert.Nil(t, responses)
+		assert.Equal(t, codes.Unavailable, status.Code(err), "status %d", statusCode)
+	}
+}
@@ -1,7 +1,6 @@
 package jsonrpc
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -10,6 +9,7 @@ import (
 	"math/rand"
 	"net/http"
 	"strings"
+	"time"
 
 	"grpc2mcp/internal/mcpconst"
 
@@ -23,6 +23,15 @@ import (
 // the same either way in NewJSONRPCRequest()
 type NewHttpRequester func(ctx context.Context, method string, url string, body io.Reader) (*http.Request, error)
 
+type requestIDKey struct{}
+
+// RequestID is the JSON-RPC id NewJSONRPCRequest gave req, for when the caller has to
+// refer back to the request, eg to cancel it. Notifications have none.
+func RequestID(req *http.Request) (jsonrpc2.ID, bool) {
+	id, ok := req.Context().Value(requestIDKey{}).(jsonrpc2.ID)
+	return id, ok
+}
+
 // This function consolidates request manipulation for a JSONRPC request. it allows
 // the caller to pass in the request constructor so we can use a mock in tests
 func NewJSONRPCRequest(ctx context.Context, url string, jsonRpcMethod mcpconst.JsonRpcMethod, params any,
@@ -41,7 +50,7 @@ func NewJSONRPCRequest(ctx context.Context, url string, jsonRpcMethod mcpconst.J
 	reqBody := &jsonrpc2.Request{
 		Method: string(jsonRpcMethod),
 		Params: rawParams,
-		ID:     jsonrpc2.ID{Num: uint64(rand.Int63())},
+		ID:     newID(),
 		Notif:  isNotification,
 	}
 
@@ -61,69 +70,348 @@ func NewJSONRPCRequest(ctx context.Context, url string, jsonRpcMethod mcpconst.J
 		req.Header.Set(header, val)
 	}
 
+	if !isNotification {
+		req = req.WithContext(context.WithValue(req.Context(), requestIDKey{}, reqBody.ID))
+	}
+
+	return req, nil
+}
+
+// newID makes up a request id. Servers that decode ids as float64 echo anything past
+// 2^53 back rounded, and the response would no longer match the request.
+func newID() jsonrpc2.ID {
+	return jsonrpc2.ID{Num: uint64(rand.Int63n(1 << 53))}
+}
+
+// NewJSONRPCResponse creates the POST which answers request id, one the server sent us,
+// with either result or rpcErr.
+func NewJSONRPCResponse(ctx context.Context, url string, id jsonrpc2.ID, result any, rpcErr *jsonrpc2.Error,
+	additionalHeaders map[string]string, reqFunc NewHttpRequester) (*http.Request, error) {
+
+	respBody := &jsonrpc2.Response{ID: id, Error: rpcErr}
+	if rpcErr == nil {
+		resultMsg, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+		respBody.Result = (*json.RawMessage)(&resultMsg)
+	}
+
+	bodyBytes, err := json.Marshal(respBody)
+	if err != nil {
+		return nil, fmt.Errorf("error putting together jsonrpc response: %w", err)
+	}
+
+	req, err := reqFunc(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("problem creating new JSONRPC response: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	for header, val := range additionalHeaders {
+		req.Header.Set(header, val)
+	}
+
 	return req, nil
 }
 
 // DoRequest sends a JSON-RPC request and handles parsing the response, correctly
 // interpreting both standard JSON and SSE (text/event-stream) formats.
 func DoRequest(ctx context.Context, client *http.Client, req *http.Request) (*jsonrpc2.Response, *http.Response, error) {
+	return DoStreamingRequest(ctx, client, req, nil)
+}
+
+// DoStreamingRequest is DoRequest for calls where the server may send notifications or
+// requests of its own on the SSE response before the result. Each of those is handed
+// to onMessage as it arrives, or dropped if onMessage is nil. The response is the one
+// whose id matches the request's, and ends the stream for us.
+func DoStreamingRequest(ctx context.Context, client *http.Client, req *http.Request,
+	onMessage func(*jsonrpc2.Request) error) (*jsonrpc2.Response, *http.Response, error) {
+	return DoResumableRequest(ctx, client, req, 0, onMessage)
+}
+
+// DoResumableRequest is DoStreamingRequest for an SSE response that may break off
+// before the result, eg when a load balancer resets the connection. If the server gave
+// its events ids, the stream is resumed from the last one with a Last-Event-ID GET, at
+// most maxResumes times over the whole request.
+func DoResumableRequest(ctx context.Context, client *http.Client, req *http.Request, maxResumes int,
+	onMessage func(*jsonrpc2.Request) error) (*jsonrpc2.Response, *http.Response, error) {
+
 	httpResp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, status.Errorf(codes.Unavailable, "failed to call mcp server: %v", err)
 	}
+	// It's important to close the body after we're done reading it.
+	defer httpResp.Body.Close()
+
+	if err := statusError(httpResp); err != nil {
+		return nil, httpResp, err
+	}
 
+	if contentType := httpResp.Header.Get("Content-Type"); strings.Contains(contentType, "text/event-stream") {
+		resp, err := readResponse(ctx, client, req, httpResp.Body, maxResumes, onMessage)
+		return resp, httpResp, err
+	}
+
+	// For application/json, read the whole body.
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, httpResp, status.Errorf(codes.Internal, "failed to read mcp server response: %v", err)
+	}
+
+	if len(respBody) == 0 {
+		// Handle cases where the body is empty but the status was OK.
+		return nil, httpResp, nil
+	}
+
+	var resp jsonrpc2.Response
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, httpResp, status.Errorf(codes.Internal, "failed to unmarshal mcp server response: %s", string(respBody))
+	}
+
+	return &resp, httpResp, nil
+}
+
+// statusError is the error for a POST the server didn't accept, nil if it did.
+func statusError(httpResp *http.Response) error {
+	if httpResp.StatusCode == http.StatusNotFound {
+		// a session the server has expired or ended, the client has to start a new one
+		body, _ := io.ReadAll(httpResp.Body)
+		return status.Errorf(codes.NotFound, "mcp server has no such session: %s", string(body))
+	}
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		body, _ := io.ReadAll(httpResp.Body)
-		_ = httpResp.Body.Close()
-		return nil, httpResp, status.Errorf(codes.Unavailable, "mcp server returned non-2xx status: %d: %s", httpResp.StatusCode, string(body))
+		return status.Errorf(codes.Unavailable, "mcp server returned non-2xx status: %d: %s", httpResp.StatusCode, string(body))
 	}
+	return nil
+}
 
-	contentType := httpResp.Header.Get("Content-Type")
-	var respBody []byte
+// readResponse reads the SSE response to req, handing the server's own messages to
+// onMessage until the response arrives. Responses to other requests are skipped. A
+// notification's stream can end without one, giving a nil response; a request's
+// can't.
+func readResponse(ctx context.Context, client *http.Client, req *http.Request, body io.Reader, maxResumes int,
+	onMessage func(*jsonrpc2.Request) error) (*jsonrpc2.Response, error) {
 
-	if strings.Contains(contentType, "text/event-stream") {
-		// For SSE, we scan for the last "data:" line.
-		scanner := bufio.NewScanner(httpResp.Body)
-		var lastData string
-		for scanner.Scan() {
-			line := scanner.Text()
-			if strings.HasPrefix(line, "data: ") {
-				lastData = strings.TrimPrefix(line, "data: ")
+	id, hasID := RequestID(req)
+
+	events := NewEventReader(body)
+	resumes := 0
+	for {
+		event, err := events.Next()
+		if err != nil {
+			// without event ids there's nowhere to resume from, and without a request
+			// id nothing to wait for
+			if !hasID || events.LastEventID() == "" || maxResumes <= 0 {
+				switch {
+				case err == io.EOF && !hasID:
+					return nil, nil
+				case err == io.EOF:
+					return nil, status.Errorf(codes.Unavailable, "mcp server SSE stream ended before the response to id %s", id)
+				}
+				return nil, status.Errorf(codes.Internal, "failed to read mcp server SSE response: %v", err)
 			}
+
+			var resumed *http.Response
+			for resumed == nil {
+				if resumes >= maxResumes {
+					return nil, status.Errorf(codes.Unavailable, "gave up resuming mcp server SSE response after %d attempts: %v", resumes, err)
+				}
+				resumes++
+				resumed, err = resumeStream(ctx, client, req, events.LastEventID(), events.Retry())
+				if ctx.Err() != nil {
+					return nil, status.FromContextError(ctx.Err()).Err()
+				}
+			}
+			defer resumed.Body.Close()
+			events.Resume(resumed.Body)
+			continue
 		}
-		if err := scanner.Err(); err != nil {
-			_ = httpResp.Body.Close()
-			return nil, httpResp, status.Errorf(codes.Internal, "failed to read mcp server SSE response: %v", err)
-		}
-		if lastData == "" {
-			// It's possible to get a 200 OK with an empty stream, e.g., for notifications.
-			// Return success with a nil response object.
-			_ = httpResp.Body.Close()
-			return nil, httpResp, nil
+		if event.Type != "message" {
+			continue
 		}
-		respBody = []byte(lastData)
-	} else {
-		// For application/json, read the whole body.
-		body, err := io.ReadAll(httpResp.Body)
+
+		msg, resp, err := decodeMessage(event.Data)
 		if err != nil {
-			_ = httpResp.Body.Close()
-			return nil, httpResp, status.Errorf(codes.Internal, "failed to read mcp server response: %v", err)
+			return nil, err
+		}
+		if resp != nil {
+			if !hasID || resp.ID == id {
+				return resp, nil
+			}
+			continue
+		}
+		if onMessage == nil {
+			continue
+		}
+		if err := onMessage(msg); err != nil {
+			return nil, err
 		}
-		respBody = body
 	}
+}
 
-	// It's important to close the body after we're done reading it.
-	_ = httpResp.Body.Close()
+// DefaultResumeDelay is how long to wait before resuming a broken SSE stream when the
+// server hasn't set a retry.
+const DefaultResumeDelay = time.Second
 
-	if len(respBody) == 0 {
-		// Handle cases where the body is empty but the status was OK.
-		return nil, httpResp, nil
+// resumeStream waits out delay then asks the server, with a GET carrying req's headers,
+// to carry on with req's SSE stream after event lastEventID.
+func resumeStream(ctx context.Context, client *http.Client, req *http.Request, lastEventID string,
+	delay time.Duration) (*http.Response, error) {
+
+	if delay <= 0 {
+		delay = DefaultResumeDelay
+	}
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 
-	var resp jsonrpc2.Response
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, httpResp, status.Errorf(codes.Internal, "failed to unmarshal mcp server response: %s", string(respBody))
+	resumeReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("problem creating resume request: %w", err)
 	}
+	resumeReq.Header = req.Header.Clone()
+	resumeReq.Header.Del("Content-Type")
+	resumeReq.Header.Set("Accept", "text/event-stream")
+	resumeReq.Header.Set(mcpconst.LastEventIDHeader, lastEventID)
 
-	return &resp, httpResp, nil
+	return OpenStream(ctx, client, resumeReq)
+}
+
+// decodeMessage decodes an SSE event's JSON-RPC message. Those with a method are the
+// server's own requests and notifications, the rest responses.
+func decodeMessage(data []byte) (*jsonrpc2.Request, *jsonrpc2.Response, error) {
+	var methodProbe struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(data, &methodProbe); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to unmarshal mcp server message: %s", string(data))
+	}
+
+	if methodProbe.Method == "" {
+		var resp jsonrpc2.Response
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to unmarshal mcp server response: %s", string(data))
+		}
+		return nil, &resp, nil
+	}
+
+	var msg jsonrpc2.Request
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to unmarshal mcp server message: %v", err)
+	}
+	return &msg, nil, nil
+}
+
+// NewListenRequest creates the GET request which opens the standalone SSE stream an
+// MCP server uses to send notifications and requests that are not tied to a POST.
+func NewListenRequest(ctx context.Context, url string, additionalHeaders map[string]string,
+	reqFunc NewHttpRequester) (*http.Request, error) {
+
+	req, err := reqFunc(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("problem creating new listen request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	for header, val := range additionalHeaders {
+		req.Header.Set(header, val)
+	}
+
+	return req, nil
+}
+
+// OpenStream sends a listen request and returns the response once the server has
+// accepted it. The caller owns the body and reads it with ReadMessages.
+func OpenStream(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to open stream to mcp server: %v", err)
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		body, _ := io.ReadAll(httpResp.Body)
+		_ = httpResp.Body.Close()
+		return nil, status.Errorf(codes.Unavailable, "mcp server returned non-2xx status: %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	if contentType := httpResp.Header.Get("Content-Type"); !strings.Contains(contentType, "text/event-stream") {
+		_ = httpResp.Body.Close()
+		return nil, status.Errorf(codes.Unimplemented, "mcp server did not open an SSE stream, got: %s", contentType)
+	}
+
+	return httpResp, nil
+}
+
+// NewTerminateRequest creates the DELETE request which ends the session named by the
+// mcp-session-id header in additionalHeaders.
+func NewTerminateRequest(ctx context.Context, url string, additionalHeaders map[string]string,
+	reqFunc NewHttpRequester) (*http.Request, error) {
+
+	req, err := reqFunc(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("problem creating new terminate request: %w", err)
+	}
+
+	for header, val := range additionalHeaders {
+		req.Header.Set(header, val)
+	}
+
+	return req, nil
+}
+
+// DoTerminate sends a terminate request. A 404 means the server doesn't know the
+// session, likely as it already ended, and a 405 that the server doesn't let clients
+// end sessions.
+func DoTerminate(ctx context.Context, client *http.Client, req *http.Request) error {
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to call mcp server: %v", err)
+	}
+	body, _ := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+
+	switch {
+	case httpResp.StatusCode >= 200 && httpResp.StatusCode < 300:
+		return nil
+	case httpResp.StatusCode == http.StatusNotFound:
+		return status.Errorf(codes.NotFound, "mcp server has no such session: %s", string(body))
+	case httpResp.StatusCode == http.StatusMethodNotAllowed:
+		return status.Errorf(codes.Unimplemented, "mcp server does not allow clients to end sessions: %s", string(body))
+	}
+	return status.Errorf(codes.Unavailable, "mcp server returned non-2xx status: %d: %s", httpResp.StatusCode, string(body))
+}
+
+// ReadMessages reads the SSE stream in body and hands every message the server sends,
+// notifications and server requests alike, to onMessage. It returns when the stream
+// ends, on a read error, or when onMessage returns an error.
+func ReadMessages(body io.Reader, onMessage func(*jsonrpc2.Request) error) error {
+	events := NewEventReader(body)
+	for {
+		event, err := events.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to read mcp server stream: %v", err)
+		}
+		if event.Type != "message" {
+			continue
+		}
+
+		msg, resp, err := decodeMessage(event.Data)
+		if err != nil {
+			return err
+		}
+		// nothing on this stream is waiting on a response
+		if resp != nil {
+			continue
+		}
+		if err := onMessage(msg); err != nil {
+			return err
+		}
+	}
 }
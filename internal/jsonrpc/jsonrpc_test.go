@@ -3,9 +3,12 @@ package jsonrpc
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"grpc2mcp/internal/mcpconst"
@@ -61,6 +64,11 @@ func TestNewJSONRPCRequest_HappyPath(t *testing.T) {
 	assert.False(t, rpcReq.Notif, "Should not be a notification")
 	require.NotNil(t, rpcReq.ID.Num, "ID should be set for a standard request")
 
+	// and the id can be had back from the request itself
+	id, ok := RequestID(req)
+	require.True(t, ok)
+	assert.Equal(t, rpcReq.ID, id)
+
 	// Verify params
 	var decodedParams struct {
 		Key string `json:"key"`
@@ -70,18 +78,52 @@ func TestNewJSONRPCRequest_HappyPath(t *testing.T) {
 	assert.Equal(t, "value", decodedParams.Key)
 }
 
+func TestNewJSONRPCResponse(t *testing.T) {
+	ctx := context.Background()
+	url := "http://localhost:8080/rpc"
+	id := jsonrpc2.ID{Num: 7}
+
+	req, err := NewJSONRPCResponse(ctx, url, id, map[string]string{"key": "value"}, nil, nil, mockNewHttpRequester)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+
+	var resp jsonrpc2.Response
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&resp))
+	assert.Equal(t, id, resp.ID)
+	assert.Nil(t, resp.Error)
+	require.NotNil(t, resp.Result)
+	assert.JSONEq(t, `{"key": "value"}`, string(*resp.Result))
+
+	// an error goes instead of the result
+	rpcErr := &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: "no such method"}
+	req, err = NewJSONRPCResponse(ctx, url, id, nil, rpcErr, nil, mockNewHttpRequester)
+	require.NoError(t, err)
+
+	resp = jsonrpc2.Response{}
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&resp))
+	assert.Nil(t, resp.Result)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcErr.Code, resp.Error.Code)
+}
+
 func TestDoRequest_SSE_HappyPath(t *testing.T) {
-	// Setup a mock server to return an SSE response
+	// Setup a mock server whose response comes between a response to some other
+	// request and a trailing notification, neither of which should be taken for it
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rpcReq jsonrpc2.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&rpcReq))
+
 		w.Header().Set("Content-Type", "text/event-stream")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":\"first event\"}\n"))
-		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":\"final event\"}\n\n"))
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":\"other\",\"result\":\"other event\"}\n\n"))
+		_, _ = fmt.Fprintf(w, "event: message\nid: 1\ndata: {\"jsonrpc\":\"2.0\",\"id\":%s,\n"+
+			"data: \"result\":\"final event\"}\n\n", rpcReq.ID)
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\",\"params\":{}}\n\n"))
 	}))
 	defer server.Close()
 
 	// Create a request to the mock server
-	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	req, err := NewJSONRPCRequest(context.Background(), server.URL, mcpconst.ToolsCall, nil, nil, http.NewRequestWithContext)
 	require.NoError(t, err)
 
 	// Execute the request
@@ -100,6 +142,125 @@ func TestDoRequest_SSE_HappyPath(t *testing.T) {
 	assert.Equal(t, "final event", result) // TODO make this a const we use above
 }
 
+func TestDoRequest_SSE_EndsWithoutResponse(t *testing.T) {
+	// the stream ends after a response to some other request, which isn't ours
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":\"other\",\"result\":\"other event\"}\n\n"))
+	}))
+	defer server.Close()
+
+	req, err := NewJSONRPCRequest(context.Background(), server.URL, mcpconst.ToolsList, nil, nil, http.NewRequestWithContext)
+	require.NoError(t, err)
+
+	rpcResp, _, err := DoRequest(context.Background(), server.Client(), req)
+	assert.Nil(t, rpcResp)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestDoStreamingRequest_SSE_HappyPath(t *testing.T) {
+	// the server's notifications come before the response on the same stream
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":1}}\n\n"))
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":2}}\n\n"))
+		_, _ = w.Write([]byte("data: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":\"final event\"}\n\n"))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	var methods []string
+	rpcResp, _, err := DoStreamingRequest(context.Background(), server.Client(), req, func(msg *jsonrpc2.Request) error {
+		methods = append(methods, msg.Method)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, rpcResp)
+	assert.Equal(t, []string{"notifications/progress", "notifications/progress"}, methods)
+
+	var result string
+	err = json.Unmarshal(*rpcResp.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, "final event", result)
+}
+
+func TestDoResumableRequest(t *testing.T) {
+	// the connection drops after the first event, the rest comes on the resumed stream
+	var mu sync.Mutex
+	var requestID string
+	var resumedFrom []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodPost {
+			var rpcReq jsonrpc2.Request
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&rpcReq))
+			requestID = rpcReq.ID.String()
+			_, _ = w.Write([]byte("retry: 10\nid: 1\n" +
+				"data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":1}}\n\n"))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+
+		assert.Equal(t, "session-1", r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+		resumedFrom = append(resumedFrom, r.Header.Get(mcpconst.LastEventIDHeader))
+		_, _ = w.Write([]byte("id: 2\n" +
+			"data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\",\"params\":{\"progress\":2}}\n\n"))
+		if len(resumedFrom) == 1 {
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = fmt.Fprintf(w, "id: 3\ndata: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":\"final event\"}\n\n", requestID)
+	}))
+	defer server.Close()
+
+	headers := map[string]string{mcpconst.MCP_SESSION_ID_HEADER: "session-1"}
+	newRequest := func() *http.Request {
+		req, err := NewJSONRPCRequest(context.Background(), server.URL, mcpconst.ToolsCall, nil, headers, http.NewRequestWithContext)
+		require.NoError(t, err)
+		return req
+	}
+
+	var progress []string
+	rpcResp, _, err := DoResumableRequest(context.Background(), server.Client(), newRequest(), 2, func(msg *jsonrpc2.Request) error {
+		progress = append(progress, string(*msg.Params))
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, rpcResp)
+	mu.Lock()
+	assert.Equal(t, []string{"1", "2"}, resumedFrom)
+	assert.Equal(t, []string{`{"progress":1}`, `{"progress":2}`, `{"progress":2}`}, progress)
+
+	var result string
+	require.NoError(t, json.Unmarshal(*rpcResp.Result, &result))
+	assert.Equal(t, "final event", result)
+
+	resumedFrom = nil
+	mu.Unlock()
+
+	// out of resumes the call fails, and without any a drop fails it straight off
+	_, _, err = DoResumableRequest(context.Background(), server.Client(), newRequest(), 1, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	mu.Lock()
+	assert.Equal(t, []string{"1"}, resumedFrom)
+	resumedFrom = nil
+	mu.Unlock()
+
+	_, _, err = DoStreamingRequest(context.Background(), server.Client(), newRequest(), nil)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	mu.Lock()
+	assert.Empty(t, resumedFrom)
+	mu.Unlock()
+}
+
 func TestDoRequest_JSON_HappyPath(t *testing.T) {
 	// Setup a mock server to return a JSON response
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -153,3 +314,26 @@ func TestDoRequest_Non2xxError(t *testing.T) {
 	assert.Equal(t, codes.Unavailable, st.Code())
 	assert.Contains(t, st.Message(), "mcp server returned non-2xx status: 500: internal server error")
 }
+
+func TestReadMessages_HappyPath(t *testing.T) {
+	sseBody := strings.NewReader("event: message\n" +
+		"data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/resources/updated\",\"params\":{\"uri\":\"test://a\"}}\n\n" +
+		"event: message\n" +
+		"data: {\"jsonrpc\":\"2.0\",\"id\":7,\"method\":\"ping\"}\n\n")
+
+	var received []*jsonrpc2.Request
+	err := ReadMessages(sseBody, func(msg *jsonrpc2.Request) error {
+		received = append(received, msg)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+
+	assert.Equal(t, "notifications/resources/updated", received[0].Method)
+	assert.True(t, received[0].Notif, "first message should be a notification")
+	assert.JSONEq(t, `{"uri":"test://a"}`, string(*received[0].Params))
+
+	assert.Equal(t, "ping", received[1].Method)
+	assert.False(t, received[1].Notif, "second message should be a request")
+	assert.Equal(t, uint64(7), received[1].ID.Num)
+}
@@ -0,0 +1,128 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is one server-sent event.
+type Event struct {
+	// Type is the event field, "message" when the server gave none
+	Type string
+	// Data is the event's data lines joined with newlines
+	Data []byte
+	// ID is the last event id the stream set, as of this event
+	ID string
+}
+
+// EventReader reads server-sent events as the HTML spec has them: events end at a
+// blank line, data may span several lines, and id and retry carry over to later events.
+// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type EventReader struct {
+	r *bufio.Reader
+	// a line ended with \r, so a \n following it ends the same line
+	skipLF bool
+
+	lastID string
+	retry  time.Duration
+}
+
+func NewEventReader(r io.Reader) *EventReader {
+	return &EventReader{r: bufio.NewReader(r)}
+}
+
+// Resume carries on reading from r, a stream resumed from LastEventID, keeping the
+// last event id and retry.
+func (er *EventReader) Resume(r io.Reader) {
+	er.r = bufio.NewReader(r)
+	er.skipLF = false
+}
+
+// LastEventID is the last event id the server set, to resume the stream from.
+func (er *EventReader) LastEventID() string {
+	return er.lastID
+}
+
+// Retry is how long the server last asked clients to wait before reconnecting, 0 if
+// it hasn't.
+func (er *EventReader) Retry() time.Duration {
+	return er.retry
+}
+
+// Next returns the next event, or io.EOF once the stream ends. An event the stream
+// ends in the middle of is dropped.
+func (er *EventReader) Next() (*Event, error) {
+	var eventType string
+	var data bytes.Buffer
+	hasData := false
+
+	for {
+		line, err := er.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if line == "" {
+			if !hasData {
+				// a block without data dispatches nothing, but id and retry still stick
+				eventType = ""
+				continue
+			}
+			if eventType == "" {
+				eventType = "message"
+			}
+			return &Event{Type: eventType, Data: bytes.TrimSuffix(data.Bytes(), []byte("\n")), ID: er.lastID}, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			// a comment, often sent to keep the connection open
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				er.lastID = value
+			}
+		case "retry":
+			if millis, err := strconv.ParseUint(value, 10, 63); err == nil {
+				er.retry = time.Duration(millis) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine reads up to the next \r\n, \n or \r, without it.
+func (er *EventReader) readLine() (string, error) {
+	var line []byte
+	for {
+		b, err := er.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		skipLF := er.skipLF
+		er.skipLF = false
+		switch {
+		case b == '\n' && skipLF:
+			continue
+		case b == '\n':
+			return string(line), nil
+		case b == '\r':
+			er.skipLF = true
+			return string(line), nil
+		}
+		line = append(line, b)
+	}
+}
@@ -0,0 +1,61 @@
+package jsonrpc
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventReader(t *testing.T) {
+	events := NewEventReader(strings.NewReader(": a comment to keep the connection open\n" +
+		"retry: 3000\n" +
+		"\n" +
+		"id: 1\n" +
+		"data: first\n" +
+		"data:second\n" +
+		"\n" +
+		"event: other\r\n" +
+		"data: crlf\r\n" +
+		"\r\n" +
+		"data: cr\r" +
+		"\r" +
+		"id: 3\n" +
+		"data: cut off"))
+
+	event, err := events.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Event{Type: "message", Data: []byte("first\nsecond"), ID: "1"}, event)
+	assert.Equal(t, 3*time.Second, events.Retry())
+
+	// the id carries over to events that don't set their own
+	event, err = events.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Event{Type: "other", Data: []byte("crlf"), ID: "1"}, event)
+
+	event, err = events.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Event{Type: "message", Data: []byte("cr"), ID: "1"}, event)
+
+	// the stream ending mid event drops it, though its id still counts
+	_, err = events.Next()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "3", events.LastEventID())
+}
+
+func TestEventReader_IgnoresBadFields(t *testing.T) {
+	events := NewEventReader(strings.NewReader("retry: soon\n" +
+		"id: bad\x00id\n" +
+		"unknown: field\n" +
+		"data\n" +
+		"\n"))
+
+	// a bare data field is an empty line of data
+	event, err := events.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &Event{Type: "message", Data: []byte{}, ID: ""}, event)
+	assert.Zero(t, events.Retry())
+}
@@ -4,6 +4,24 @@ package mcpconst
 var MCP_SESSION_ID_HEADER = "mcp-session-id"
 var AuthorizationHeader = "authorization"
 
+// LastEventIDHeader is how a client resuming a broken SSE stream tells the server the
+// last event it got
+var LastEventIDHeader = "Last-Event-ID"
+
+// ProgressTokenKey is where a request's _meta carries the token its progress
+// notifications refer back to
+const ProgressTokenKey = "progressToken"
+
+// ProtocolVersion is the MCP revision the protos were derived from
+const ProtocolVersion = "2025-06-18"
+
+// BatchingProtocolVersion is the one MCP revision with JSON-RPC batching, which the
+// revisions after it dropped again
+const BatchingProtocolVersion = "2025-03-26"
+
+// ProtocolVersionHeader is the header clients name their session's MCP revision with
+var ProtocolVersionHeader = "mcp-protocol-version"
+
 // Method is a typed string for JSON-RPC method names.
 type JsonRpcMethod string
 
@@ -11,6 +29,40 @@ type JsonRpcMethod string
 const (
 	Initialize               JsonRpcMethod = "initialize"
 	NotificationsInitialized JsonRpcMethod = "notifications/initialized"
+	NotificationsCancelled   JsonRpcMethod = "notifications/cancelled"
 	ToolsCall                JsonRpcMethod = "tools/call"
+	ToolsList                JsonRpcMethod = "tools/list"
 	Ping                     JsonRpcMethod = "ping"
+	PromptsGet               JsonRpcMethod = "prompts/get"
+	ResourcesRead            JsonRpcMethod = "resources/read"
+	ResourcesSubscribe       JsonRpcMethod = "resources/subscribe"
+	ResourcesUnsubscribe     JsonRpcMethod = "resources/unsubscribe"
+	CompletionComplete       JsonRpcMethod = "completion/complete"
+	LoggingSetLevel          JsonRpcMethod = "logging/setLevel"
+	SamplingCreateMessage    JsonRpcMethod = "sampling/createMessage"
+	ElicitationCreate        JsonRpcMethod = "elicitation/create"
+	RootsList                JsonRpcMethod = "roots/list"
+
+	NotificationsResourcesUpdated JsonRpcMethod = "notifications/resources/updated"
+	NotificationsProgress         JsonRpcMethod = "notifications/progress"
+	NotificationsMessage          JsonRpcMethod = "notifications/message"
+	NotificationsRootsListChanged JsonRpcMethod = "notifications/roots/list_changed"
+
+	NotificationsToolsListChanged     JsonRpcMethod = "notifications/tools/list_changed"
+	NotificationsPromptsListChanged   JsonRpcMethod = "notifications/prompts/list_changed"
+	NotificationsResourcesListChanged JsonRpcMethod = "notifications/resources/list_changed"
+)
+
+// the type of a completion/complete ref, for prompts and resource templates respectively
+const (
+	RefPrompt   = "ref/prompt"
+	RefResource = "ref/resource"
 )
+
+// BackendHeader is the gRPC metadata key naming which MCP server a call is for, when
+// the proxy fronts several
+const BackendHeader = "x-mcp-backend"
+
+// BackendToolSeparator separates a backend's name from a tool's, as in github/create_issue.
+// MCP tool names can't have a slash of their own.
+const BackendToolSeparator = "/"
@@ -0,0 +1,284 @@
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Aggregate has the router present its backends as one MCP server. Calls that don't
+// name a backend with x-mcp-backend list the tools, prompts, resources and resource
+// templates of every backend, each named with its backend's prefix, eg github/create_issue,
+// and calls with those names go back to the owning backend. Listing goes through the
+// proxy's own sessions with each backend, so the backends need ManageSessions or
+// PoolSessions. A backend that fails to list is logged and left out of that listing,
+// the call only fails if they all do. It has to be called before starting.
+func (r *Router) Aggregate() {
+	r.aggregate = true
+}
+
+// aggregating is whether a call should see the backends as one
+func (r *Router) aggregating(ctx context.Context) bool {
+	if !r.aggregate {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get(mcpconst.BackendHeader)) == 0
+}
+
+// routeNamespaced is route for the names and uris an aggregated listing handed out.
+// Unlike tool names those can have a slash of their own, so anything without a known
+// backend's prefix goes to the default backend as it is.
+func (r *Router) routeNamespaced(ctx context.Context, name string) (*Server, string, error) {
+	if r.aggregating(ctx) {
+		if backendName, rest, ok := strings.Cut(name, mcpconst.BackendToolSeparator); ok {
+			if backend, ok := r.backends[backendName]; ok {
+				return backend, rest, nil
+			}
+		}
+	}
+	backend, _, err := r.route(ctx, "")
+	return backend, name, err
+}
+
+func namespaced(backendName, name string) string {
+	return backendName + mcpconst.BackendToolSeparator + name
+}
+
+// withoutCallerSession drops the caller's mcp-session-id from aggregated calls. A
+// session is with one backend and no good for the others, so the proxy uses its own.
+func (r *Router) withoutCallerSession(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && r.aggregating(ctx) {
+		md = md.Copy()
+		md.Delete(mcpconst.MCP_SESSION_ID_HEADER)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+func (r *Router) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(r.withoutCallerSession(ctx), req)
+}
+
+func (r *Router) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &sessionServerStream{ss, r.withoutCallerSession(ss.Context())})
+}
+
+// aggregateCursor is the page each backend is up to. Backends that have given their
+// last page are left out, and the cursor for a backend's first page is empty.
+type aggregateCursor map[string]string
+
+func (c aggregateCursor) encode() *string {
+	if len(c) == 0 {
+		return nil
+	}
+	cursorJson, _ := json.Marshal(c)
+	cursor := base64.RawURLEncoding.EncodeToString(cursorJson)
+	return &cursor
+}
+
+func (r *Router) decodeCursor(cursor string) (aggregateCursor, error) {
+	if cursor == "" {
+		first := aggregateCursor{}
+		for name := range r.backends {
+			first[name] = ""
+		}
+		return first, nil
+	}
+
+	cursorJson, err := base64.RawURLEncoding.DecodeString(cursor)
+	var decoded aggregateCursor
+	if err == nil {
+		err = json.Unmarshal(cursorJson, &decoded)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %v", err)
+	}
+	for name := range decoded {
+		if _, ok := r.backends[name]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor, no such backend: %s", name)
+		}
+	}
+	return decoded, nil
+}
+
+// aggregateList lists a page from every backend in cursor at once. Pages come back by
+// backend name, along with the cursor for the next page. A backend that fails is
+// logged and dropped from the listing, so one that's down doesn't hide the rest; it's
+// an error only if every backend fails.
+func aggregateList[Resp interface{ GetNextCursor() string }](ctx context.Context, r *Router, cursor string,
+	list func(ctx context.Context, backend *Server, cursor *string) (Resp, error)) (map[string]Resp, *string, error) {
+
+	cursors, err := r.decodeCursor(cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := map[string]error{}
+	pages := map[string]Resp{}
+	next := aggregateCursor{}
+	for name, backendCursor := range cursors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var cursorPtr *string
+			if backendCursor != "" {
+				cursorPtr = &backendCursor
+			}
+			page, err := list(ctx, r.backends[name], cursorPtr)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed[name] = status.Errorf(status.Code(err), "backend %s: %v", name, status.Convert(err).Message())
+				return
+			}
+			pages[name] = page
+			if page.GetNextCursor() != "" {
+				next[name] = page.GetNextCursor()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if len(pages) == 0 && len(failed) > 0 {
+		return nil, nil, failed[sortedNames(failed)[0]]
+	}
+	for _, name := range sortedNames(failed) {
+		log.Printf("leaving backend %s out of the aggregated listing: %v", name, failed[name])
+	}
+	return pages, next.encode(), nil
+}
+
+// sortedNames is the names of pages in order, so a listing comes out the same each time
+func sortedNames[Resp any](pages map[string]Resp) []string {
+	names := make([]string, 0, len(pages))
+	for name := range pages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (r *Router) aggregateTools(ctx context.Context, req *mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	pages, next, err := aggregateList(ctx, r, req.GetCursor(), func(ctx context.Context, backend *Server, cursor *string) (*mcp.ListToolsResult, error) {
+		req := proto.Clone(req).(*mcp.ListToolsRequest)
+		req.Cursor = cursor
+		return routeUnary(ctx, backend, req, (*Server).ListTools)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mcp.ListToolsResult{NextCursor: next}
+	for _, name := range sortedNames(pages) {
+		for _, tool := range pages[name].GetTools() {
+			tool.Name = namespaced(name, tool.GetName())
+			result.Tools = append(result.Tools, tool)
+		}
+	}
+	return result, nil
+}
+
+func (r *Router) aggregatePrompts(ctx context.Context, req *mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	pages, next, err := aggregateList(ctx, r, req.GetCursor(), func(ctx context.Context, backend *Server, cursor *string) (*mcp.ListPromptsResult, error) {
+		req := proto.Clone(req).(*mcp.ListPromptsRequest)
+		req.Cursor = cursor
+		return routeUnary(ctx, backend, req, (*Server).ListPrompts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mcp.ListPromptsResult{NextCursor: next}
+	for _, name := range sortedNames(pages) {
+		for _, prompt := range pages[name].GetPrompts() {
+			prompt.Name = namespaced(name, prompt.GetName())
+			result.Prompts = append(result.Prompts, prompt)
+		}
+	}
+	return result, nil
+}
+
+func (r *Router) aggregateResources(ctx context.Context, req *mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	pages, next, err := aggregateList(ctx, r, req.GetCursor(), func(ctx context.Context, backend *Server, cursor *string) (*mcp.ListResourcesResult, error) {
+		req := proto.Clone(req).(*mcp.ListResourcesRequest)
+		req.Cursor = cursor
+		return routeUnary(ctx, backend, req, (*Server).ListResources)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mcp.ListResourcesResult{NextCursor: next}
+	for _, name := range sortedNames(pages) {
+		for _, resource := range pages[name].GetResources() {
+			resource.Name = namespaced(name, resource.GetName())
+			resource.Uri = namespaced(name, resource.GetUri())
+			result.Resources = append(result.Resources, resource)
+		}
+	}
+	return result, nil
+}
+
+func (r *Router) aggregateResourceTemplates(ctx context.Context, req *mcp.ListResourceTemplatesRequest) (*mcp.ListResourceTemplatesResult, error) {
+	pages, next, err := aggregateList(ctx, r, req.GetCursor(), func(ctx context.Context, backend *Server, cursor *string) (*mcp.ListResourceTemplatesResult, error) {
+		req := proto.Clone(req).(*mcp.ListResourceTemplatesRequest)
+		req.Cursor = cursor
+		return routeUnary(ctx, backend, req, (*Server).ListResourceTemplates)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mcp.ListResourceTemplatesResult{NextCursor: next}
+	for _, name := range sortedNames(pages) {
+		for _, template := range pages[name].GetResourceTemplates() {
+			template.Name = namespaced(name, template.GetName())
+			template.UriTemplate = namespaced(name, template.GetUriTemplate())
+			result.ResourceTemplates = append(result.ResourceTemplates, template)
+		}
+	}
+	return result, nil
+}
+
+// aggregateCallMethodStream routes each call on the stream to its own backend, as the
+// tools on it can be from any of them.
+func (r *Router) aggregateCallMethodStream(stream mcp.ModelContextProtocol_CallMethodStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		resp, err := r.CallMethod(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
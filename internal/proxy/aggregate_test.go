@@ -0,0 +1,233 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func newAggregateBufconClient(t *testing.T, backends map[string]*Server) pb.ModelContextProtocolClient {
+	for _, backend := range backends {
+		backend.ManageSessions(0)
+	}
+	r, err := NewRouter(backends, "github")
+	require.NoError(t, err)
+	r.Aggregate()
+	t.Cleanup(func() { r.Close() })
+	return newRouterBufconClient(t, r)
+}
+
+func TestAggregate(t *testing.T) {
+
+	assert := assert.New(t)
+	mcpGrpcClient := newAggregateBufconClient(t, newExampleBackends(t, "github", "jira"))
+
+	// every backend's tools, under their backend's name
+	var toolsExpected []string
+	for _, backend := range []string{"github", "jira"} {
+		for _, tool := range examplemcp.GetProvidedToolNames() {
+			toolsExpected = append(toolsExpected, backend+"/"+tool)
+		}
+	}
+	listToolsResult, err := mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+	require.NoError(t, err)
+	var toolsFound []string
+	for _, tool := range listToolsResult.GetTools() {
+		toolsFound = append(toolsFound, tool.GetName())
+	}
+	assert.ElementsMatch(toolsExpected, toolsFound)
+	assert.Empty(listToolsResult.GetNextCursor())
+
+	// and the names lead back to them, on their own or on a stream
+	callToolRequest, err := toolTestData[0].NewToolRequest()
+	require.NoError(t, err)
+	callToolRequest.Name = "jira/" + callToolRequest.GetName()
+	callToolResult, err := mcpGrpcClient.CallMethod(t.Context(), callToolRequest)
+	require.NoError(t, err)
+	validateCallToolResult(t, callToolResult, toolTestData[0])
+
+	stream, err := mcpGrpcClient.CallMethodStream(t.Context())
+	require.NoError(t, err)
+	for _, backend := range []string{"github", "jira"} {
+		streamRequest := proto.Clone(callToolRequest).(*pb.CallToolRequest)
+		streamRequest.Name = backend + "/" + toolTestData[0].tool
+		require.NoError(t, stream.Send(streamRequest))
+	}
+	require.NoError(t, stream.CloseSend())
+	for range 2 {
+		callToolResult, err := stream.Recv()
+		require.NoError(t, err)
+		validateCallToolResult(t, callToolResult, toolTestData[0])
+	}
+
+	// prompts
+	listPromptsResult, err := mcpGrpcClient.ListPrompts(t.Context(), &pb.ListPromptsRequest{})
+	require.NoError(t, err)
+	assert.Len(listPromptsResult.GetPrompts(), 2*len(examplemcp.GetProvidedPrompts()))
+	_, err = mcpGrpcClient.GetPrompt(t.Context(), &pb.GetPromptRequest{Name: "jira/" + examplemcp.PROMPT_GREET})
+	require.NoError(t, err)
+
+	// as do resource templates, which completions can name too
+	completeResult, err := mcpGrpcClient.Complete(t.Context(), &pb.CompleteRequest{
+		Ref: &pb.Reference{RefOneof: &pb.Reference_ResourceTemplate{
+			ResourceTemplate: &pb.ResourceTemplateReference{Uri: "jira/" + examplemcp.RESOURCE_URI_TEMPLATE_GREETING},
+		}},
+		Argument: &pb.CompletionArgument{Name: examplemcp.PARAM_WHOM, Value: "a"},
+	})
+	require.NoError(t, err)
+	assert.Equal([]string{"alice"}, completeResult.GetCompletion().GetValues())
+
+	// resources come back under the uri they were asked for
+	listResourcesResult, err := mcpGrpcClient.ListResources(t.Context(), &pb.ListResourcesRequest{})
+	require.NoError(t, err)
+	var resourceUris []string
+	for _, resource := range listResourcesResult.GetResources() {
+		resourceUris = append(resourceUris, resource.GetUri())
+	}
+	jiraUri := "jira/" + examplemcp.RESOURCE_URI_STATIC
+	assert.Contains(resourceUris, jiraUri)
+	readResourceResult, err := mcpGrpcClient.ReadResource(t.Context(), &pb.ReadResourceRequest{Uri: jiraUri})
+	require.NoError(t, err)
+	require.NotEmpty(t, readResourceResult.GetContents())
+	assert.Equal(jiraUri, readResourceResult.GetContents()[0].GetText().GetUri())
+
+	// naming a backend gets the one backend, as is
+	jiraCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.BackendHeader, "jira")
+	listToolsResult, err = mcpGrpcClient.ListTools(jiraCtx, &pb.ListToolsRequest{})
+	require.NoError(t, err)
+	assert.Len(listToolsResult.GetTools(), len(examplemcp.GetProvidedToolNames()))
+	assert.NotContains(listToolsResult.GetTools()[0].GetName(), "/")
+
+	_, err = mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{Cursor: proto.String("not a cursor")})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+// a server with its tools spread over pages, which the example server doesn't do
+func newPagingMcpServer(t *testing.T, pages [][]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var msg jsonrpc2.Request
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch mcpconst.JsonRpcMethod(msg.Method) {
+		case mcpconst.Initialize:
+			w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"%s"}}`, msg.ID, mcpconst.ProtocolVersion)
+		case mcpconst.ToolsList:
+			var params struct {
+				Cursor string `json:"cursor"`
+			}
+			if msg.Params != nil {
+				require.NoError(t, json.Unmarshal(*msg.Params, &params))
+			}
+			page := 0
+			if params.Cursor != "" {
+				_, err := fmt.Sscanf(params.Cursor, "page-%d", &page)
+				require.NoError(t, err)
+			}
+			result := map[string]any{"tools": []map[string]any{}}
+			for _, name := range pages[page] {
+				result["tools"] = append(result["tools"].([]map[string]any), map[string]any{"name": name, "inputSchema": map[string]any{"type": "object"}})
+			}
+			if page+1 < len(pages) {
+				result["nextCursor"] = fmt.Sprintf("page-%d", page+1)
+			}
+			resultJson, _ := json.Marshal(result)
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, msg.ID, resultJson)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+}
+
+func TestAggregatePaging(t *testing.T) {
+
+	github := newPagingMcpServer(t, [][]string{{"a", "b"}, {"c"}, {"d"}})
+	defer github.Close()
+	jira := newPagingMcpServer(t, [][]string{{"x"}, {"y"}})
+	defer jira.Close()
+
+	backends := map[string]*Server{}
+	for name, ts := range map[string]*httptest.Server{"github": github, "jira": jira} {
+		s, err := NewServer(ts.URL)
+		require.NoError(t, err)
+		backends[name] = s
+	}
+	mcpGrpcClient := newAggregateBufconClient(t, backends)
+
+	// each page has the next page of every backend that has one left
+	var pages [][]string
+	req := &pb.ListToolsRequest{}
+	for {
+		listToolsResult, err := mcpGrpcClient.ListTools(t.Context(), req)
+		require.NoError(t, err)
+		var page []string
+		for _, tool := range listToolsResult.GetTools() {
+			page = append(page, tool.GetName())
+		}
+		pages = append(pages, page)
+		if listToolsResult.GetNextCursor() == "" {
+			break
+		}
+		req.Cursor = listToolsResult.NextCursor
+	}
+
+	assert.Equal(t, [][]string{
+		{"github/a", "github/b", "jira/x"},
+		{"github/c", "jira/y"},
+		{"github/d"},
+	}, pages)
+}
+
+func TestAggregateFailingBackend(t *testing.T) {
+
+	assert := assert.New(t)
+
+	github := newPagingMcpServer(t, [][]string{{"a"}, {"b"}})
+	defer github.Close()
+	jira := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer jira.Close()
+
+	backends := map[string]*Server{}
+	for name, ts := range map[string]*httptest.Server{"github": github, "jira": jira} {
+		s, err := NewServer(ts.URL)
+		require.NoError(t, err)
+		backends[name] = s
+	}
+	mcpGrpcClient := newAggregateBufconClient(t, backends)
+
+	// a backend that's down is left out, the others still list
+	listToolsResult, err := mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listToolsResult.GetTools(), 1)
+	assert.Equal("github/a", listToolsResult.GetTools()[0].GetName())
+
+	listToolsResult, err = mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{Cursor: listToolsResult.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, listToolsResult.GetTools(), 1)
+	assert.Equal("github/b", listToolsResult.GetTools()[0].GetName())
+	assert.Empty(listToolsResult.GetNextCursor())
+
+	// but with none left there's nothing to list
+	github.Close()
+	_, err = mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+	assert.Equal(codes.Unavailable, status.Code(err))
+}
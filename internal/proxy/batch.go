@@ -0,0 +1,181 @@
+package proxy
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/internal/stdio"
+	mcp "grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxConcurrentBatchCalls is how many of a batch's calls are made at once when they
+// can't go as one JSON-RPC batch
+const maxConcurrentBatchCalls = 16
+
+// batchingSessions are the sessions that negotiated MCP 2025-03-26, whose server takes
+// JSON-RPC batches.
+type batchingSessions struct {
+	mu       sync.Mutex
+	sessions map[string]bool
+}
+
+// set notes the protocolVersion sessionID negotiated.
+func (bs *batchingSessions) set(sessionID, protocolVersion string) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if protocolVersion != mcpconst.BatchingProtocolVersion {
+		delete(bs.sessions, sessionID)
+		return
+	}
+	if bs.sessions == nil {
+		bs.sessions = map[string]bool{}
+	}
+	bs.sessions[sessionID] = true
+}
+
+func (bs *batchingSessions) has(sessionID string) bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.sessions[sessionID]
+}
+
+func (bs *batchingSessions) forget(sessionID string) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	delete(bs.sessions, sessionID)
+}
+
+// CallMethodBatch implements the CallMethodBatch RPC. A session that negotiated MCP
+// 2025-03-26, as the proxy saw it initialize or as the caller's mcp-protocol-version
+// header says, gets the calls as one JSON-RPC batch. Any other session, a stdio
+// backend, and a server that turns the batch away even so, gets them as separate calls
+// made side by side. Either way each call gets a result or error of its own, in
+// request order.
+//
+// The sessions the proxy opens itself, managed and pooled ones, ask for
+// mcpconst.ProtocolVersion, so they only batch if the server settles on 2025-03-26
+// instead. A caller that wants real batches has to ask for 2025-03-26 in its own
+// Initialize or send it in the mcp-protocol-version header; otherwise, against the
+// example server too, a batch is as many POSTs.
+func (s *Server) CallMethodBatch(ctx context.Context, req *mcp.CallToolBatchRequest) (*mcp.CallToolBatchResult, error) {
+	if len(req.GetRequests()) == 0 {
+		return &mcp.CallToolBatchResult{}, nil
+	}
+	if !s.sendsBatches(ctx) {
+		return s.callEach(ctx, req.GetRequests())
+	}
+
+	result, err := s.callBatch(ctx, req.GetRequests())
+	if status.Code(err) == codes.Unimplemented {
+		// mcp-go for one negotiates 2025-03-26 with clients that don't say, batches or no
+		log.Printf("MCP session %s turned a batch away, making the calls one by one: %v", sessionIDFromContext(ctx), err)
+		s.batching.forget(sessionIDFromContext(ctx))
+		return s.callEach(ctx, req.GetRequests())
+	}
+	return result, err
+}
+
+// sendsBatches is whether ctx's session takes JSON-RPC batches. The stdio transport
+// passes on one message at a time, so it never does.
+func (s *Server) sendsBatches(ctx context.Context) bool {
+	if _, ok := s.httpClient.Transport.(*stdio.Transport); ok {
+		return false
+	}
+	if s.batching.has(sessionIDFromContext(ctx)) {
+		return true
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	versions := md.Get(mcpconst.ProtocolVersionHeader)
+	return len(versions) > 0 && versions[0] == mcpconst.BatchingProtocolVersion
+}
+
+// callBatch sends the calls as one JSON-RPC batch and picks each one's response out by
+// its id. If ctx ends first, the server is told each call is cancelled.
+func (s *Server) callBatch(ctx context.Context, reqs []*mcp.CallToolRequest) (*mcp.CallToolBatchResult, error) {
+
+	params := make([]any, len(reqs))
+	for i, req := range reqs {
+		params[i] = req
+	}
+	additionalHeaders := initHttpHeadersFromContext(ctx)
+	httpReq, ids, err := jsonrpc.NewJSONRPCBatchRequest(ctx, s.mcpUrl, mcpconst.ToolsCall, params, additionalHeaders, http.NewRequestWithContext)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create http request for %s batch: %v", mcpconst.ToolsCall, err)
+	}
+
+	responses, err := jsonrpc.DoBatchRequest(ctx, &s.httpClient, httpReq, ids, s.withServerRequests(ctx, nil))
+	if status.Code(err) == codes.NotFound {
+		if retryReq, ok := s.retryExpiredSession(ctx, httpReq); ok {
+			ctx = withSessionID(ctx, retryReq.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+			responses, err = jsonrpc.DoBatchRequest(ctx, &s.httpClient, retryReq, ids, s.withServerRequests(ctx, nil))
+		}
+	}
+	if err != nil && ctx.Err() != nil {
+		for _, id := range ids {
+			s.sendCancelled(ctx, id, ctx.Err().Error())
+		}
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mcp.CallToolBatchResult{Responses: make([]*mcp.CallToolBatchResponse, len(ids))}
+	for i, id := range ids {
+		result.Responses[i] = batchResponse(decodeBatchedResult(responses, id))
+	}
+	return result, nil
+}
+
+// decodeBatchedResult decodes the response to call id of a batch.
+func decodeBatchedResult(responses map[jsonrpc2.ID]*jsonrpc2.Response, id jsonrpc2.ID) (*mcp.CallToolResult, error) {
+	resp, ok := responses[id]
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "MCP server sent no response to call %s of the batch", id)
+	}
+	return decodeCallToolResult(resp)
+}
+
+// callEach makes the calls one POST apiece, maxConcurrentBatchCalls at a time.
+func (s *Server) callEach(ctx context.Context, reqs []*mcp.CallToolRequest) (*mcp.CallToolBatchResult, error) {
+
+	result := &mcp.CallToolBatchResult{Responses: make([]*mcp.CallToolBatchResponse, len(reqs))}
+	slots := make(chan struct{}, maxConcurrentBatchCalls)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result.Responses[i] = batchResponse(s.doCallMethodRpc(ctx, req))
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return result, nil
+}
+
+// batchResponse is a call's place in a CallToolBatchResult.
+func batchResponse(result *mcp.CallToolResult, err error) *mcp.CallToolBatchResponse {
+	if err != nil {
+		st := status.Convert(err)
+		return &mcp.CallToolBatchResponse{Response: &mcp.CallToolBatchResponse_Error{
+			Error: &mcp.CallError{Code: int32(st.Code()), Message: st.Message()},
+		}}
+	}
+	return &mcp.CallToolBatchResponse{Response: &mcp.CallToolBatchResponse_Result{Result: result}}
+}
@@ -0,0 +1,197 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCallMethodBatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(examplemcp.RunExampleMcpServer(t.Name(), "/mcp"))
+	defer ts.Close()
+	s, err := NewServer(ts.URL)
+	require.NoError(t, err)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoError(t, err)
+
+	// mcp-go negotiates 2025-03-26 with a client that doesn't name a revision, but turns
+	// batches away all the same, so these end up being made one by one
+	batch := &pb.CallToolBatchRequest{}
+	for _, ttd := range toolTestData {
+		callToolRequest, err := ttd.NewToolRequest()
+		require.NoError(t, err)
+		batch.Requests = append(batch.Requests, callToolRequest)
+	}
+	batch.Requests = append(batch.Requests, &pb.CallToolRequest{Name: "no-such-tool"})
+
+	result, err := mcpGrpcClient.CallMethodBatch(sessionCtx, batch)
+	require.NoError(t, err)
+	require.Len(t, result.GetResponses(), len(toolTestData)+1)
+	for i, ttd := range toolTestData {
+		validateCallToolResult(t, result.GetResponses()[i].GetResult(), ttd)
+	}
+	// a call that fails doesn't take the rest of the batch down with it
+	assert.Equal(int32(codes.Aborted), result.GetResponses()[len(toolTestData)].GetError().GetCode())
+	// and the session isn't asked to take a batch again
+	md, _ := metadata.FromOutgoingContext(sessionCtx)
+	assert.False(s.batching.has(md.Get(mcpconst.MCP_SESSION_ID_HEADER)[0]))
+
+	empty, err := mcpGrpcClient.CallMethodBatch(sessionCtx, &pb.CallToolBatchRequest{})
+	require.NoError(t, err)
+	assert.Empty(empty.GetResponses())
+}
+
+// batchingMcpServer is a canned MCP server on revision 2025-03-26, which takes
+// JSON-RPC batches. Each tool's result is its name.
+type batchingMcpServer struct {
+	mu      sync.Mutex
+	batches int
+	singles int
+}
+
+func (b *batchingMcpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	result := func(msg jsonrpc2.Request) string {
+		var params struct {
+			Name string `json:"name"`
+		}
+		_ = json.Unmarshal(*msg.Params, &params)
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":{"content":[{"type":"text","text":"%s"}]}}`, msg.ID, params.Name)
+	}
+
+	var batch []jsonrpc2.Request
+	if json.Unmarshal(body, &batch) == nil {
+		b.mu.Lock()
+		b.batches++
+		b.mu.Unlock()
+		// answered backwards, it's the ids that say which is which
+		var responses []string
+		for i := len(batch) - 1; i >= 0; i-- {
+			responses = append(responses, result(batch[i]))
+		}
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(responses, ","))
+		return
+	}
+
+	var msg jsonrpc2.Request
+	_ = json.Unmarshal(body, &msg)
+	switch mcpconst.JsonRpcMethod(msg.Method) {
+	case mcpconst.Initialize:
+		w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"%s"}}`, msg.ID, mcpconst.BatchingProtocolVersion)
+	case mcpconst.NotificationsInitialized:
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		b.mu.Lock()
+		b.singles++
+		b.mu.Unlock()
+		_, _ = w.Write([]byte(result(msg)))
+	}
+}
+
+func (b *batchingMcpServer) counts() (batches, singles int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.batches, b.singles
+}
+
+func TestCallMethodBatch_JSONRPCBatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	canned := &batchingMcpServer{}
+	mcpServer := httptest.NewServer(canned)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	batch := &pb.CallToolBatchRequest{Requests: []*pb.CallToolRequest{{Name: "add"}, {Name: "mult"}, {Name: "lower"}}}
+	toolNames := func(result *pb.CallToolBatchResult) []string {
+		var names []string
+		for _, response := range result.GetResponses() {
+			names = append(names, response.GetResult().GetContent()[0].GetText().GetText())
+		}
+		return names
+	}
+
+	// the session negotiated 2025-03-26 through the proxy, so the calls go as one batch
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoError(t, err)
+	result, err := mcpGrpcClient.CallMethodBatch(sessionCtx, batch)
+	require.NoError(t, err)
+	assert.Equal([]string{"add", "mult", "lower"}, toolNames(result))
+	batches, singles := canned.counts()
+	assert.Equal(1, batches)
+	assert.Zero(singles)
+
+	// a session the proxy didn't see start goes by the caller's protocol version header
+	otherCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.MCP_SESSION_ID_HEADER, "session-2")
+	_, err = mcpGrpcClient.CallMethodBatch(otherCtx, batch)
+	require.NoError(t, err)
+	_, singles = canned.counts()
+	assert.Equal(3, singles)
+
+	otherCtx = metadata.AppendToOutgoingContext(otherCtx, mcpconst.ProtocolVersionHeader, mcpconst.BatchingProtocolVersion)
+	result, err = mcpGrpcClient.CallMethodBatch(otherCtx, batch)
+	require.NoError(t, err)
+	assert.Equal([]string{"add", "mult", "lower"}, toolNames(result))
+	batches, _ = canned.counts()
+	assert.Equal(2, batches)
+}
+
+func TestRouterCallMethodBatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	backends := newExampleBackends(t, "github", "jira")
+	for _, backend := range backends {
+		backend.ManageSessions(0)
+	}
+	r, err := NewRouter(backends, "github")
+	require.NoError(t, err)
+	defer r.Close()
+	mcpGrpcClient := newRouterBufconClient(t, r)
+
+	// each backend gets its share of the calls, and the results come back in order
+	batch := &pb.CallToolBatchRequest{}
+	for _, name := range []string{"jira", "github", "confluence", ""} {
+		callToolRequest, err := toolTestData[0].NewToolRequest()
+		require.NoError(t, err)
+		if name != "" {
+			callToolRequest.Name = name + mcpconst.BackendToolSeparator + callToolRequest.GetName()
+		}
+		batch.Requests = append(batch.Requests, callToolRequest)
+	}
+
+	result, err := mcpGrpcClient.CallMethodBatch(t.Context(), batch)
+	require.NoError(t, err)
+	require.Len(t, result.GetResponses(), 4)
+	validateCallToolResult(t, result.GetResponses()[0].GetResult(), toolTestData[0])
+	validateCallToolResult(t, result.GetResponses()[1].GetResult(), toolTestData[0])
+	assert.Equal(int32(codes.NotFound), result.GetResponses()[2].GetError().GetCode())
+	validateCallToolResult(t, result.GetResponses()[3].GetResult(), toolTestData[0])
+}
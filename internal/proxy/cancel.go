@@ -0,0 +1,81 @@
+package proxy
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// how long we give the MCP server to take a notifications/cancelled
+const cancelNotifyTimeout = 5 * time.Second
+
+// doRequest sends httpReq to the MCP server. If ctx ends before the server answers, ie
+// the gRPC client cancelled or hit its deadline, the server is sent a
+// notifications/cancelled so it can stop working on it. An SSE response that breaks
+// off is resumed, as long as the server numbered its events. Requests the server makes
+// on the response are handled, its notifications handed to onMessage.
+func (s *Server) doRequest(ctx context.Context, httpReq *http.Request,
+	onMessage func(*jsonrpc2.Request) error) (*jsonrpc2.Response, *http.Response, error) {
+
+	id, ok := jsonrpc.RequestID(httpReq)
+	if !ok {
+		return jsonrpc.DoStreamingRequest(ctx, &s.httpClient, httpReq, s.withServerRequests(ctx, onMessage))
+	}
+
+	resp, httpResp, err := jsonrpc.DoResumableRequest(ctx, &s.httpClient, httpReq, s.maxResumes, s.withServerRequests(ctx, onMessage))
+	if status.Code(err) == codes.NotFound {
+		if retryReq, ok := s.retryExpiredSession(ctx, httpReq); ok {
+			// from here on the call belongs to the session it continues in
+			ctx = withSessionID(ctx, retryReq.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+			resp, httpResp, err = jsonrpc.DoResumableRequest(ctx, &s.httpClient, retryReq, s.maxResumes, s.withServerRequests(ctx, onMessage))
+		}
+	}
+	if err != nil && ctx.Err() != nil {
+		s.sendCancelled(ctx, id, ctx.Err().Error())
+		return nil, httpResp, status.FromContextError(ctx.Err()).Err()
+	}
+	return resp, httpResp, err
+}
+
+// sendCancelled tells the MCP server we've given up on request id. It's best effort,
+// the server may have finished already and is free to ignore it.
+func (s *Server) sendCancelled(ctx context.Context, id jsonrpc2.ID, reason string) {
+
+	// ctx is already done, but its headers still say which session this is for
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelNotifyTimeout)
+	defer cancel()
+
+	params := map[string]any{"requestId": id, "reason": reason}
+	httpReq, err := jsonrpc.NewJSONRPCRequest(cancelCtx, s.mcpUrl, mcpconst.NotificationsCancelled, params,
+		initHttpHeadersFromContext(ctx), http.NewRequestWithContext)
+	if err != nil {
+		log.Printf("failed to create %s for request %s: %v", mcpconst.NotificationsCancelled, id, err)
+		return
+	}
+	if _, _, err := jsonrpc.DoRequest(cancelCtx, &s.httpClient, httpReq); err != nil {
+		log.Printf("failed to send %s for request %s: %v", mcpconst.NotificationsCancelled, id, err)
+	}
+}
+
+// withServerRequests wraps onMessage to handle the requests the server makes on a POST's
+// stream, which would otherwise leave the server, and so the call, waiting on them.
+func (s *Server) withServerRequests(ctx context.Context, onMessage func(*jsonrpc2.Request) error) func(*jsonrpc2.Request) error {
+	return func(msg *jsonrpc2.Request) error {
+		if !msg.Notif {
+			s.handleServerRequest(ctx, msg)
+			return nil
+		}
+		if onMessage == nil {
+			return nil
+		}
+		return onMessage(msg)
+	}
+}
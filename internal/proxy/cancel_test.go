@@ -0,0 +1,127 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// the example server has no notion of cancellation, so a canned server that never
+// answers a tool call stands in for an expensive tool
+func TestCancelledCallNotifiesServer(t *testing.T) {
+
+	assert := assert.New(t)
+
+	called := make(chan jsonrpc2.ID, 1)
+	cancelled := make(chan json.RawMessage, 1)
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var msg jsonrpc2.Request
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		switch mcpconst.JsonRpcMethod(msg.Method) {
+		case mcpconst.ToolsCall:
+			called <- msg.ID
+			<-r.Context().Done()
+		case mcpconst.NotificationsCancelled:
+			assert.Equal("session-1", r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+			cancelled <- *msg.Params
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+
+	md := metadata.Pairs(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+	ctx, cancel := context.WithTimeout(metadata.NewIncomingContext(t.Context(), md), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = s.CallMethod(ctx, &pb.CallToolRequest{Name: "slow"})
+	require.Error(t, err)
+	assert.Equal(codes.DeadlineExceeded, status.Code(err))
+
+	calledID := <-called
+	select {
+	case params := <-cancelled:
+		var cancelParams struct {
+			RequestID jsonrpc2.ID `json:"requestId"`
+			Reason    string      `json:"reason"`
+		}
+		require.NoError(t, json.Unmarshal(params, &cancelParams))
+		assert.Equal(calledID, cancelParams.RequestID)
+		assert.Equal(context.DeadlineExceeded.Error(), cancelParams.Reason)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server never got notifications/cancelled")
+	}
+}
+
+// a managed session that expires mid-call carries on in a new one, which is the one the
+// server has to be told to stop
+func TestCancelledCallNotifiesRenewedSession(t *testing.T) {
+
+	assert := assert.New(t)
+
+	cancelledIn := make(chan string, 1)
+	var mu sync.Mutex
+	opened := 0
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var msg jsonrpc2.Request
+		require.NoError(t, json.Unmarshal(body, &msg))
+		session := r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER)
+
+		switch mcpconst.JsonRpcMethod(msg.Method) {
+		case mcpconst.Initialize:
+			mu.Lock()
+			opened++
+			w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, fmt.Sprintf("session-%d", opened))
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"%s"}}`, msg.ID, mcpconst.ProtocolVersion)
+		case mcpconst.ToolsCall:
+			if session == "session-1" {
+				http.Error(w, "Session terminated", http.StatusNotFound)
+				return
+			}
+			<-r.Context().Done()
+		case mcpconst.NotificationsCancelled:
+			cancelledIn <- session
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer mcpServer.Close()
+
+	mcpGrpcClient := newManagedBufconClient(t, mcpServer.URL)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	defer cancel()
+	_, err := mcpGrpcClient.CallMethod(ctx, &pb.CallToolRequest{Name: "slow"})
+	assert.Equal(codes.DeadlineExceeded, status.Code(err))
+
+	select {
+	case session := <-cancelledIn:
+		assert.Equal("session-2", session)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server never got notifications/cancelled")
+	}
+}
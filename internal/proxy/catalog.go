@@ -0,0 +1,122 @@
+package proxy
+
+import (
+	"context"
+	"log"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// WatchCatalog implements the WatchCatalog RPC. It holds the session's GET/SSE stream
+// open and, each time the server says its tools, prompts or resources changed, fetches
+// that list again and sends it on, until the client goes away. A list that can't be
+// fetched, say one longer than the proxy's page limit, is logged and skipped, the
+// client hears of the next change.
+func (s *Server) WatchCatalog(req *mcp.WatchCatalogRequest, stream mcp.ModelContextProtocol_WatchCatalogServer) error {
+	ctx := stream.Context()
+
+	httpResp, err := s.openListenStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	// sending the headers lets the client know the stream is listening
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	err = jsonrpc.ReadMessages(httpResp.Body, func(msg *jsonrpc2.Request) error {
+		if !msg.Notif {
+			s.handleServerRequest(ctx, msg)
+			return nil
+		}
+		change, err := s.fetchCatalogChange(ctx, mcpconst.JsonRpcMethod(msg.Method))
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			log.Printf("skipping %s, failed to fetch the changed list: %v", msg.Method, err)
+			return nil
+		}
+		if change == nil {
+			return nil
+		}
+		return stream.Send(change)
+	})
+
+	// the client hanging up is how the stream normally ends
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// fetchCatalogChange fetches the list a list_changed notification is about, nil for
+// any other notification.
+func (s *Server) fetchCatalogChange(ctx context.Context, notification mcpconst.JsonRpcMethod) (*mcp.CatalogChange, error) {
+	switch notification {
+	case mcpconst.NotificationsToolsListChanged:
+		tools, err := s.fetchTools(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CatalogChange{Catalog: &mcp.CatalogChange_Tools{Tools: tools}}, nil
+	case mcpconst.NotificationsPromptsListChanged:
+		prompts, err := s.fetchPrompts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CatalogChange{Catalog: &mcp.CatalogChange_Prompts{Prompts: prompts}}, nil
+	case mcpconst.NotificationsResourcesListChanged:
+		resources, err := s.fetchResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CatalogChange{Catalog: &mcp.CatalogChange_Resources{Resources: resources}}, nil
+	}
+	return nil, nil
+}
+
+// fetchTools lists every page of the server's tools as one result, following no more
+// pages than the ListAll RPCs would.
+func (s *Server) fetchTools(ctx context.Context) (*mcp.ListToolsResult, error) {
+	result := &mcp.ListToolsResult{}
+	err := listAll(ctx, &mcp.ListAllRequest{}, s.maxListPages, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListToolsResult, error) {
+		return s.ListTools(ctx, &mcp.ListToolsRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListToolsResult).GetTools, func(tool *mcp.Tool) error {
+		result.Tools = append(result.Tools, tool)
+		return nil
+	})
+	return result, err
+}
+
+// fetchPrompts is fetchTools for prompts.
+func (s *Server) fetchPrompts(ctx context.Context) (*mcp.ListPromptsResult, error) {
+	result := &mcp.ListPromptsResult{}
+	err := listAll(ctx, &mcp.ListAllRequest{}, s.maxListPages, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListPromptsResult, error) {
+		return s.ListPrompts(ctx, &mcp.ListPromptsRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListPromptsResult).GetPrompts, func(prompt *mcp.Prompt) error {
+		result.Prompts = append(result.Prompts, prompt)
+		return nil
+	})
+	return result, err
+}
+
+// fetchResources is fetchTools for resources.
+func (s *Server) fetchResources(ctx context.Context) (*mcp.ListResourcesResult, error) {
+	result := &mcp.ListResourcesResult{}
+	err := listAll(ctx, &mcp.ListAllRequest{}, s.maxListPages, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListResourcesResult, error) {
+		return s.ListResources(ctx, &mcp.ListResourcesRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListResourcesResult).GetResources, func(resource *mcp.Resource) error {
+		result.Resources = append(result.Resources, resource)
+		return nil
+	})
+	return result, err
+}
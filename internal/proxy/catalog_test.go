@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchCatalog_SkipsFailedFetch(t *testing.T) {
+
+	// a canned server whose tools go on page after page, and which says its tools and
+	// then its prompts changed as soon as the proxy listens
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			for _, method := range []mcpconst.JsonRpcMethod{mcpconst.NotificationsToolsListChanged, mcpconst.NotificationsPromptsListChanged} {
+				_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"%s\"}\n\n", method)
+			}
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var msg jsonrpc2.Request
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch msg.Method {
+		case string(mcpconst.Initialize):
+			w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"%s"}}`, msg.ID, mcpconst.ProtocolVersion)
+		case string(mcpconst.ToolsList):
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"tools":[],"nextCursor":"more"}}`, msg.ID)
+		case "prompts/list":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"prompts":[{"name":"greet"}]}}`, msg.ID)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.ManageSessions(0)
+	s.LimitListPages(2)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	// cancelling is how a client stops watching, the timeout keeps us from hanging
+	streamCtx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	stream, err := mcpGrpcClient.WatchCatalog(streamCtx, &pb.WatchCatalogRequest{})
+	require.NoError(t, err)
+
+	// the tools run past the page limit, which costs that change but not the stream
+	change, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, change.GetPrompts())
+	assert.Equal(t, "greet", change.GetPrompts().GetPrompts()[0].GetName())
+}
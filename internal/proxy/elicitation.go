@@ -0,0 +1,67 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultElicitTimeout is how long an elicitation waits on the client before the proxy
+// cancels it for them.
+const DefaultElicitTimeout = 5 * time.Minute
+
+// TimeoutElicitations sets how long an elicitation waits on the client, 0 for
+// DefaultElicitTimeout.
+func (s *Server) TimeoutElicitations(timeout time.Duration) {
+	s.elicitTimeout = timeout
+}
+
+func (s *Server) elicitationTimeout() time.Duration {
+	if s.elicitTimeout <= 0 {
+		return DefaultElicitTimeout
+	}
+	return s.elicitTimeout
+}
+
+// elicitResult is an elicitation/create result as the MCP server expects it, with the
+// action a lower case name.
+type elicitResult struct {
+	Action  string           `json:"action"`
+	Content *structpb.Struct `json:"content,omitempty"`
+	Meta    *structpb.Struct `json:"_meta,omitempty"`
+}
+
+// decodeElicitRequest decodes the params of an elicitation/create.
+func decodeElicitRequest(params *json.RawMessage) (*mcp.ElicitRequest, error) {
+	if params == nil {
+		return nil, fmt.Errorf("elicitation request has no params")
+	}
+
+	var elicit mcp.ElicitRequest
+	if err := json.Unmarshal(*params, &elicit); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal elicitation request: %w", err)
+	}
+	if elicit.GetRequestedSchema() == nil {
+		return nil, fmt.Errorf("elicitation request has no requestedSchema")
+	}
+	return &elicit, nil
+}
+
+// encodeElicitResult puts the client's elicitation result in the shape the MCP server
+// expects it.
+func encodeElicitResult(result *mcp.ElicitResult) (any, error) {
+	if result.GetAction() == mcp.ElicitAction_ELICIT_ACTION_UNSPECIFIED {
+		return nil, fmt.Errorf("elicitation result needs an action")
+	}
+
+	return elicitResult{
+		Action:  strings.ToLower(result.GetAction().String()),
+		Content: result.Content,
+		Meta:    result.GetXMeta(),
+	}, nil
+}
@@ -0,0 +1,114 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// the example server can't elicit, so a canned server asks twice on its GET stream and
+// collects the answers POSTed back
+func TestSessionElicitation(t *testing.T) {
+
+	assert := assert.New(t)
+
+	answers := make(chan jsonrpc2.Response, 2)
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			var resp jsonrpc2.Response
+			require.NoError(t, json.Unmarshal(body, &resp))
+			answers <- resp
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		for id, message := range map[int]string{7: "who are you?", 8: "are you still there?"} {
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%d,\"method\":\"%s\",\"params\":"+
+				"{\"message\":\"%s\",\"requestedSchema\":{\"type\":\"object\",\"properties\":{\"name\":{\"type\":\"string\"}}}}}\n\n",
+				id, mcpconst.ElicitationCreate, message)
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	const elicitTimeout = 200 * time.Millisecond
+	s.TimeoutElicitations(elicitTimeout)
+
+	lis := bufconn.Listen(1024 * 1024)
+	serverCancel, err := s.StartProxyToListenerAsync(lis)
+	require.NoError(t, err)
+	defer serverCancel()
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	md := metadata.Pairs(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(t.Context(), md), 5*time.Second)
+	defer cancel()
+
+	stream, err := pb.NewModelContextProtocolClient(conn).Session(ctx)
+	require.NoError(t, err)
+
+	elicits := map[string]*pb.ElicitRequest{}
+	for range 2 {
+		serverRequest, err := stream.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, serverRequest.GetElicit(), "expected an elicit request")
+		elicits[serverRequest.GetRequestId()] = serverRequest.GetElicit()
+	}
+	require.Contains(t, elicits, "7")
+	require.Contains(t, elicits, "8")
+	assert.Equal("who are you?", elicits["7"].GetMessage())
+	assert.Equal("string", elicits["7"].GetRequestedSchema().GetFields()["properties"].GetStructValue().
+		GetFields()["name"].GetStructValue().GetFields()["type"].GetStringValue())
+
+	content, err := structpb.NewStruct(map[string]any{"name": "alice"})
+	require.NoError(t, err)
+	err = stream.Send(&pb.ClientResponse{
+		RequestId: "7",
+		Response:  &pb.ClientResponse_Elicit{Elicit: &pb.ElicitResult{Action: pb.ElicitAction_ACCEPT, Content: content}},
+	})
+	require.NoError(t, err)
+
+	// the one we answered gets our answer, the other is cancelled once it times out
+	results := map[string]json.RawMessage{}
+	for range 2 {
+		select {
+		case resp := <-answers:
+			require.Nil(t, resp.Error)
+			require.NotNil(t, resp.Result)
+			results[resp.ID.String()] = *resp.Result
+		case <-time.After(10 * elicitTimeout):
+			t.Fatal("server never got an answer")
+		}
+	}
+	assert.JSONEq(`{"action": "accept", "content": {"name": "alice"}}`, string(results["7"]))
+	assert.JSONEq(`{"action": "cancel"}`, string(results["8"]))
+}
@@ -19,7 +19,10 @@ func methodRequiresMcpSessionHeader(method string) bool {
 }
 
 // TODO figure out why and explain the reason for recopying these context vars
-func getInterceptorContext(ctx context.Context, method string) (context.Context, error) {
+// the returned func has to be called with the call's outcome once it's done.
+func (s *Server) getInterceptorContext(ctx context.Context, method string) (context.Context, func(error), error) {
+
+	done := func(error) {}
 
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -43,9 +46,29 @@ func getInterceptorContext(ctx context.Context, method string) (context.Context,
 			sessionID = md.Get(strings.ToLower(mcpconst.MCP_SESSION_ID_HEADER))
 		}
 
-		// if still empty, report an error
+		// if still empty, borrow a pooled session for anonymous callers, use a session
+		// we manage for the caller or else report an error
+		if len(sessionID) == 0 && s.pool != nil && len(authorizationHeader) == 0 {
+			pooledID, err := s.pool.borrow(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			sessionID = []string{pooledID}
+			done = func(err error) {
+				// a session the server no longer knows, or the caller ended, is no good to anyone
+				reuse := status.Code(err) != codes.NotFound && !strings.HasSuffix(method, "/Terminate")
+				s.pool.release(pooledID, reuse)
+			}
+		}
+		if len(sessionID) == 0 && s.sessions != nil {
+			managedCtx, err := s.withManagedSession(ctx, md)
+			if err != nil {
+				return nil, nil, err
+			}
+			return metadata.NewIncomingContext(managedCtx, md), done, nil
+		}
 		if len(sessionID) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "missing header: %s", mcpconst.MCP_SESSION_ID_HEADER)
+			return nil, nil, status.Errorf(codes.Unauthenticated, "missing header: %s", mcpconst.MCP_SESSION_ID_HEADER)
 		}
 
 		md.Set(mcpconst.MCP_SESSION_ID_HEADER, sessionID[0])
@@ -54,33 +77,44 @@ func getInterceptorContext(ctx context.Context, method string) (context.Context,
 	// now let's create a new context with the md we've assembled
 	newCtx := metadata.NewIncomingContext(ctx, md)
 
-	return newCtx, nil
+	return newCtx, done, nil
 }
 
 // sessionInterceptor is a gRPC unary interceptor that checks for the MCP_SESSION_ID_HEADER header.
-func unarySessionInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+func (s *Server) unarySessionInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 
-	returnCtx, err := getInterceptorContext(ctx, info.FullMethod)
+	returnCtx, done, err := s.getInterceptorContext(ctx, info.FullMethod)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return handler(returnCtx, req)
+	resp, err := handler(returnCtx, req)
+	done(err)
+	return resp, err
 }
 
-func streamSessionInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+// sessionServerStream hands stream handlers the context the interceptor put together.
+type sessionServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *sessionServerStream) Context() context.Context {
+	return ss.ctx
+}
 
-	newCtx, err := getInterceptorContext(ss.Context(), info.FullMethod)
+func (s *Server) streamSessionInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	newCtx, done, err := s.getInterceptorContext(ss.Context(), info.FullMethod)
 
 	if err != nil {
 		return err
 	}
-	type serverStream struct {
-		grpc.ServerStream
-		ctx context.Context
-	}
-	return handler(srv, &serverStream{ss, newCtx})
+
+	err = handler(srv, &sessionServerStream{ss, newCtx})
+	done(err)
+	return err
 
 }
 
@@ -0,0 +1,143 @@
+package proxy
+
+import (
+	"context"
+
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultMaxListPages is how many pages a ListAll RPC follows before giving up on a
+// server that won't stop handing out cursors.
+const DefaultMaxListPages = 100
+
+// LimitListPages sets how many pages the ListAll RPCs follow, 0 for DefaultMaxListPages.
+func (s *Server) LimitListPages(maxPages int) {
+	s.maxListPages = maxPages
+}
+
+// LimitListPages sets how many pages the ListAll RPCs follow, 0 for DefaultMaxListPages.
+func (r *Router) LimitListPages(maxPages int) {
+	r.maxListPages = maxPages
+}
+
+// lister is either a Server or a Router, whatever's serving the list RPCs
+type lister interface {
+	ListTools(context.Context, *mcp.ListToolsRequest) (*mcp.ListToolsResult, error)
+	ListPrompts(context.Context, *mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error)
+	ListResources(context.Context, *mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error)
+	ListResourceTemplates(context.Context, *mcp.ListResourceTemplatesRequest) (*mcp.ListResourceTemplatesResult, error)
+}
+
+// listAll follows the pages of a list, sending each item as its page arrives. limit is
+// the server's cap on pages, which the request can only lower, to at least one.
+func listAll[Page interface{ GetNextCursor() string }, Item any](ctx context.Context, req *mcp.ListAllRequest, limit int,
+	list func(ctx context.Context, cursor *string, meta *structpb.Struct) (Page, error),
+	items func(Page) []Item, send func(Item) error) error {
+
+	maxPages := limit
+	if maxPages <= 0 {
+		maxPages = DefaultMaxListPages
+	}
+	if req.MaxPages != nil && req.GetMaxPages() <= 0 {
+		return status.Errorf(codes.InvalidArgument, "maxPages has to be at least 1, not %d", req.GetMaxPages())
+	}
+	if req.MaxPages != nil && int(req.GetMaxPages()) < maxPages {
+		maxPages = int(req.GetMaxPages())
+	}
+
+	var cursor *string
+	for range maxPages {
+		page, err := list(ctx, cursor, req.GetXMeta())
+		if err != nil {
+			return err
+		}
+		for _, item := range items(page) {
+			if err := send(item); err != nil {
+				return err
+			}
+		}
+
+		if page.GetNextCursor() == "" {
+			return nil
+		}
+		nextCursor := page.GetNextCursor()
+		cursor = &nextCursor
+	}
+
+	return status.Errorf(codes.ResourceExhausted, "stopped after %d pages, there are more", maxPages)
+}
+
+func listAllTools(l lister, limit int, req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllToolsServer) error {
+	ctx := stream.Context()
+	return listAll(ctx, req, limit, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListToolsResult, error) {
+		return l.ListTools(ctx, &mcp.ListToolsRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListToolsResult).GetTools, stream.Send)
+}
+
+func listAllPrompts(l lister, limit int, req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllPromptsServer) error {
+	ctx := stream.Context()
+	return listAll(ctx, req, limit, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListPromptsResult, error) {
+		return l.ListPrompts(ctx, &mcp.ListPromptsRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListPromptsResult).GetPrompts, stream.Send)
+}
+
+func listAllResources(l lister, limit int, req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllResourcesServer) error {
+	ctx := stream.Context()
+	return listAll(ctx, req, limit, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListResourcesResult, error) {
+		return l.ListResources(ctx, &mcp.ListResourcesRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListResourcesResult).GetResources, stream.Send)
+}
+
+func listAllResourceTemplates(l lister, limit int, req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllResourceTemplatesServer) error {
+	ctx := stream.Context()
+	return listAll(ctx, req, limit, func(ctx context.Context, cursor *string, meta *structpb.Struct) (*mcp.ListResourceTemplatesResult, error) {
+		return l.ListResourceTemplates(ctx, &mcp.ListResourceTemplatesRequest{Cursor: cursor, XMeta: meta})
+	}, (*mcp.ListResourceTemplatesResult).GetResourceTemplates, stream.Send)
+}
+
+// ListAllTools implements the ListAllTools RPC, streaming every page of ListTools.
+func (s *Server) ListAllTools(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllToolsServer) error {
+	return listAllTools(s, s.maxListPages, req, stream)
+}
+
+// ListAllPrompts implements the ListAllPrompts RPC, streaming every page of ListPrompts.
+func (s *Server) ListAllPrompts(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllPromptsServer) error {
+	return listAllPrompts(s, s.maxListPages, req, stream)
+}
+
+// ListAllResources implements the ListAllResources RPC, streaming every page of ListResources.
+func (s *Server) ListAllResources(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllResourcesServer) error {
+	return listAllResources(s, s.maxListPages, req, stream)
+}
+
+// ListAllResourceTemplates implements the ListAllResourceTemplates RPC, streaming every
+// page of ListResourceTemplates.
+func (s *Server) ListAllResourceTemplates(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllResourceTemplatesServer) error {
+	return listAllResourceTemplates(s, s.maxListPages, req, stream)
+}
+
+// ListAllTools implements the ListAllTools RPC over the router's ListTools, so each page
+// is routed, or aggregated, just like a page asked for directly.
+func (r *Router) ListAllTools(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllToolsServer) error {
+	return listAllTools(r, r.maxListPages, req, stream)
+}
+
+// ListAllPrompts implements the ListAllPrompts RPC over the router's ListPrompts.
+func (r *Router) ListAllPrompts(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllPromptsServer) error {
+	return listAllPrompts(r, r.maxListPages, req, stream)
+}
+
+// ListAllResources implements the ListAllResources RPC over the router's ListResources.
+func (r *Router) ListAllResources(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllResourcesServer) error {
+	return listAllResources(r, r.maxListPages, req, stream)
+}
+
+// ListAllResourceTemplates implements the ListAllResourceTemplates RPC over the router's
+// ListResourceTemplates.
+func (r *Router) ListAllResourceTemplates(req *mcp.ListAllRequest, stream mcp.ModelContextProtocol_ListAllResourceTemplatesServer) error {
+	return listAllResourceTemplates(r, r.maxListPages, req, stream)
+}
@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"io"
+	"testing"
+
+	"grpc2mcp/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// recvToolNames reads the stream to its end, returning the names and how it ended
+func recvToolNames(t *testing.T, stream pb.ModelContextProtocol_ListAllToolsClient) ([]string, error) {
+	var names []string
+	for {
+		tool, err := stream.Recv()
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return names, err
+		}
+		names = append(names, tool.GetName())
+	}
+}
+
+func TestListAllPaging(t *testing.T) {
+
+	assert := assert.New(t)
+
+	ts := newPagingMcpServer(t, [][]string{{"a", "b"}, {"c"}, {"d"}})
+	defer ts.Close()
+
+	s, err := NewServer(ts.URL)
+	require.NoError(t, err)
+	s.ManageSessions(0)
+	s.LimitListPages(2)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	// asking for more pages than the server allows gets no more than that
+	stream, err := mcpGrpcClient.ListAllTools(t.Context(), &pb.ListAllRequest{MaxPages: proto.Int32(3)})
+	require.NoError(t, err)
+	names, err := recvToolNames(t, stream)
+	assert.Equal([]string{"a", "b", "c"}, names)
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+
+	stream, err = mcpGrpcClient.ListAllTools(t.Context(), &pb.ListAllRequest{MaxPages: proto.Int32(1)})
+	require.NoError(t, err)
+	names, err = recvToolNames(t, stream)
+	assert.Equal([]string{"a", "b"}, names)
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// no pages at all isn't a list, and gets no request to the server
+	for _, maxPages := range []int32{0, -1} {
+		stream, err = mcpGrpcClient.ListAllTools(t.Context(), &pb.ListAllRequest{MaxPages: proto.Int32(maxPages)})
+		require.NoError(t, err)
+		names, err = recvToolNames(t, stream)
+		assert.Empty(names)
+		assert.Equal(codes.InvalidArgument, status.Code(err))
+	}
+
+	s.LimitListPages(0)
+	stream, err = mcpGrpcClient.ListAllTools(t.Context(), &pb.ListAllRequest{})
+	require.NoError(t, err)
+	names, err = recvToolNames(t, stream)
+	require.NoError(t, err)
+	assert.Equal([]string{"a", "b", "c", "d"}, names)
+}
+
+func TestListAllAggregate(t *testing.T) {
+
+	github := newPagingMcpServer(t, [][]string{{"a", "b"}, {"c"}})
+	defer github.Close()
+	jira := newPagingMcpServer(t, [][]string{{"x"}})
+	defer jira.Close()
+
+	githubServer, err := NewServer(github.URL)
+	require.NoError(t, err)
+	jiraServer, err := NewServer(jira.URL)
+	require.NoError(t, err)
+	mcpGrpcClient := newAggregateBufconClient(t, map[string]*Server{"github": githubServer, "jira": jiraServer})
+
+	stream, err := mcpGrpcClient.ListAllTools(t.Context(), &pb.ListAllRequest{})
+	require.NoError(t, err)
+	names, err := recvToolNames(t, stream)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github/a", "github/b", "jira/x", "github/c"}, names)
+}
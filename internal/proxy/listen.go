@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openListenStream opens the session's standalone GET/SSE stream, which is where an
+// MCP server sends the notifications and requests that don't belong to a POST. The
+// stream lives as long as ctx does; the caller reads it with jsonrpc.ReadMessages
+// and closes the body when done.
+func (s *Server) openListenStream(ctx context.Context) (*http.Response, error) {
+	additionalHeaders := initHttpHeadersFromContext(ctx)
+	httpReq, err := jsonrpc.NewListenRequest(ctx, s.mcpUrl, additionalHeaders, http.NewRequestWithContext)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create listen request: %v", err)
+	}
+
+	return jsonrpc.OpenStream(ctx, &s.httpClient, httpReq)
+}
+
+// handleServerRequest answers a request the server sends on a stream other than the
+// Session stream: ping and roots/list the proxy answers itself, anything else goes to
+// the client if it has a Session stream open, or is refused if not.
+func (s *Server) handleServerRequest(ctx context.Context, msg *jsonrpc2.Request) {
+	if s.answerServerRequest(ctx, msg) {
+		return
+	}
+	if relay := s.sessionStreams.get(sessionIDFromContext(ctx)); relay != nil {
+		if err := s.relayServerRequest(relay, msg); err != nil {
+			// the stream closed under us, so the client never got it
+			log.Printf("failed to relay %s request %s: %v", msg.Method, msg.ID, err)
+			if req, ok := relay.pending.take(msg.ID.String()); ok {
+				s.giveUpOn(ctx, req)
+			}
+		}
+		return
+	}
+	s.refuseServerRequest(ctx, msg)
+}
+
+// answerServerRequest answers the requests the proxy can for any client, ping and
+// roots/list, so a server gets them answered whichever stream is reading. It reports
+// whether msg was one of them.
+func (s *Server) answerServerRequest(ctx context.Context, msg *jsonrpc2.Request) bool {
+	var result any
+	switch mcpconst.JsonRpcMethod(msg.Method) {
+	case mcpconst.Ping:
+		result = struct{}{}
+	case mcpconst.RootsList:
+		result = s.listRoots(ctx)
+	default:
+		return false
+	}
+
+	// not every server takes answers like these well, which is no reason to end the stream
+	if err := s.respondToServer(ctx, msg.ID, result, nil); err != nil {
+		log.Printf("failed to answer %s request %s: %v", msg.Method, msg.ID, err)
+	}
+	return true
+}
+
+// refuseServerRequest tells the server the client can't answer msg.
+func (s *Server) refuseServerRequest(ctx context.Context, msg *jsonrpc2.Request) {
+	rpcErr := &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: fmt.Sprintf("client does not support %s", msg.Method)}
+	if err := s.respondToServer(ctx, msg.ID, nil, rpcErr); err != nil {
+		log.Printf("failed to refuse %s request %s: %v", msg.Method, msg.ID, err)
+	}
+}
@@ -0,0 +1,152 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// a canned server asks for sampling on its GET stream while only StreamLogs is reading
+// it, with no Session stream to relay to, and should be told no rather than left waiting
+func TestListenStreamRefusesServerRequests(t *testing.T) {
+
+	answers := make(chan jsonrpc2.Response, 1)
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			var resp jsonrpc2.Response
+			require.NoError(t, json.Unmarshal(body, &resp))
+			answers <- resp
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":3,\"method\":\"%s\",\"params\":{\"messages\":[],\"maxTokens\":10}}\n\n",
+			mcpconst.SamplingCreateMessage)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	md := metadata.Pairs(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(t.Context(), md), 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.StreamLogs(ctx, &pb.StreamLogsRequest{})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	select {
+	case answer := <-answers:
+		assert.Equal(t, jsonrpc2.ID{Num: 3}, answer.ID)
+		require.NotNil(t, answer.Error)
+		assert.Equal(t, int64(jsonrpc2.CodeMethodNotFound), answer.Error.Code)
+	case <-ctx.Done():
+		t.Fatal("server's request was never answered")
+	}
+}
+
+// a canned server asks for sampling on the stream of a tool call rather than its GET
+// stream, which the client should still get on its Session stream
+func TestSessionRelaysRequestsOnCallStreams(t *testing.T) {
+
+	assert := assert.New(t)
+
+	answers := make(chan jsonrpc2.Response, 1)
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var msg struct {
+			ID     jsonrpc2.ID      `json:"id"`
+			Method string           `json:"method"`
+			Result *json.RawMessage `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(body, &msg))
+		if msg.Method == "" {
+			answers <- jsonrpc2.Response{ID: msg.ID, Result: msg.Result}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"sample-1\",\"method\":\"%s\",\"params\":"+
+			"{\"messages\":[{\"role\":\"user\",\"content\":{\"type\":\"text\",\"text\":\"hi\"}}],\"maxTokens\":10}}\n\n",
+			mcpconst.SamplingCreateMessage)
+		w.(http.Flusher).Flush()
+		select {
+		case answer := <-answers:
+			var sampled struct {
+				Content struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			}
+			require.NoError(t, json.Unmarshal(*answer.Result, &sampled))
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"%s\"}]}}\n\n",
+				msg.ID, sampled.Content.Text)
+		case <-r.Context().Done():
+		}
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	md := metadata.Pairs(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(t.Context(), md), 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.Session(ctx)
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	results := make(chan *pb.CallToolResult, 1)
+	go func() {
+		result, err := mcpGrpcClient.CallMethod(ctx, &pb.CallToolRequest{Name: "anything"})
+		assert.NoError(err)
+		results <- result
+	}()
+
+	serverRequest, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotNil(t, serverRequest.GetCreateMessage())
+	require.NoError(t, stream.Send(&pb.ClientResponse{
+		RequestId: serverRequest.GetRequestId(),
+		Response: &pb.ClientResponse_CreateMessage{CreateMessage: &pb.CreateMessageResult{
+			Role:    pb.Role_ASSISTANT,
+			Content: &pb.ContentBlock{ContentType: &pb.ContentBlock_Text{Text: &pb.TextContent{Text: "hello"}}},
+			Model:   "test-model",
+		}},
+	}))
+
+	result := <-results
+	require.Len(t, result.GetContent(), 1)
+	assert.Equal("hello", result.GetContent()[0].GetText().GetText())
+}
@@ -0,0 +1,85 @@
+package proxy
+
+import (
+	"context"
+	"strings"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// SetLoggingLevel implements the SetLoggingLevel RPC, telling the server the least
+// severe log messages it should send the session.
+func (s *Server) SetLoggingLevel(ctx context.Context, req *mcp.SetLevelRequest) (*mcp.SetLevelResult, error) {
+	if err := s.setLoggingLevel(ctx, req); err != nil {
+		return nil, err
+	}
+	return &mcp.SetLevelResult{}, nil
+}
+
+// setLoggingLevel sends logging/setLevel. Levels go on the wire as lower case names,
+// which encoding/json won't make of the enum for us.
+func (s *Server) setLoggingLevel(ctx context.Context, req *mcp.SetLevelRequest) error {
+	if req.GetLevel() == mcp.LoggingLevel_LOGGING_LEVEL_UNSPECIFIED {
+		return status.Errorf(codes.InvalidArgument, "a logging level is needed")
+	}
+
+	params := struct {
+		Level string           `json:"level"`
+		Meta  *structpb.Struct `json:"_meta,omitempty"`
+	}{Level: strings.ToLower(req.GetLevel().String()), Meta: req.GetXMeta()}
+
+	var result struct{}
+	return s.doRpcCall(ctx, params, mcpconst.LoggingSetLevel, &result)
+}
+
+// StreamLogs implements the StreamLogs RPC. It holds the session's GET/SSE stream open
+// and sends on every notifications/message until the client goes away.
+func (s *Server) StreamLogs(req *mcp.StreamLogsRequest, stream mcp.ModelContextProtocol_StreamLogsServer) error {
+	ctx := stream.Context()
+
+	httpResp, err := s.openListenStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if req.Level != nil {
+		if err := s.setLoggingLevel(ctx, &mcp.SetLevelRequest{Level: req.GetLevel()}); err != nil {
+			return err
+		}
+	}
+
+	// sending the headers lets the client know the stream is listening
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	err = jsonrpc.ReadMessages(httpResp.Body, func(msg *jsonrpc2.Request) error {
+		if !msg.Notif {
+			s.handleServerRequest(ctx, msg)
+			return nil
+		}
+		if msg.Method != string(mcpconst.NotificationsMessage) || msg.Params == nil {
+			return nil
+		}
+		logMessage, err := decodeLoggingMessage(*msg.Params)
+		if err != nil {
+			return err
+		}
+		return stream.Send(logMessage)
+	})
+
+	// the client hanging up is how the stream normally ends
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,283 @@
+package proxy
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultManagedSessionMaxIdle is how long a managed session may go unused before the
+// proxy ends it.
+const DefaultManagedSessionMaxIdle = 30 * time.Minute
+
+// managedSessions are the MCP sessions the proxy opens on behalf of callers that don't
+// send an mcp-session-id of their own. A caller is known by its authorization header,
+// callers without one share a session. Sessions unused for maxIdle are ended, so
+// callers that have gone away don't hold on to theirs.
+type managedSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*managedSession
+	open     func(ctx context.Context) (string, error)
+	end      func(ctx context.Context, sessionID string) error
+	maxIdle  time.Duration
+	stop     context.CancelFunc
+	done     chan struct{}
+}
+
+type managedSession struct {
+	mu sync.Mutex
+	id string
+	// lastUsed is when the session was last asked for, guarded by managedSessions.mu
+	lastUsed time.Time
+	// dropped is set once the session is no longer in managedSessions, whoever still
+	// holds it has to look it up again
+	dropped bool
+}
+
+type managedSessionKey struct{}
+
+func newManagedSessions(open func(ctx context.Context) (string, error), end func(ctx context.Context, sessionID string) error,
+	maxIdle time.Duration) *managedSessions {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &managedSessions{sessions: map[string]*managedSession{}, open: open, end: end, maxIdle: maxIdle, stop: cancel,
+		done: make(chan struct{})}
+	go m.run(ctx)
+	return m
+}
+
+func (m *managedSessions) entry(key string) *managedSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[key]
+	if !ok {
+		session = &managedSession{}
+		m.sessions[key] = session
+	}
+	session.lastUsed = time.Now()
+	return session
+}
+
+// lookup returns key's session locked, one that hasn't been dropped.
+func (m *managedSessions) lookup(key string) *managedSession {
+	for {
+		session := m.entry(key)
+		session.mu.Lock()
+		if !session.dropped {
+			return session
+		}
+		session.mu.Unlock()
+	}
+}
+
+// get returns key's session, opening one the first time it's asked for.
+func (m *managedSessions) get(ctx context.Context, key string) (string, error) {
+	return m.renew(ctx, key, "")
+}
+
+// renew returns key's session, opening a new one if it has none or it still has stale,
+// the session the server no longer knows. Callers racing to renew the same stale
+// session all get the one replacement.
+func (m *managedSessions) renew(ctx context.Context, key, stale string) (string, error) {
+	session := m.lookup(key)
+	defer session.mu.Unlock()
+
+	if session.id != "" && session.id != stale {
+		return session.id, nil
+	}
+	id, err := m.open(ctx)
+	if err != nil {
+		return "", err
+	}
+	session.id = id
+	return id, nil
+}
+
+// forget drops key's session if it's still id, eg once it's been terminated.
+func (m *managedSessions) forget(key, id string) {
+	m.mu.Lock()
+	session, ok := m.sessions[key]
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	session.mu.Lock()
+	forgotten := session.id == id
+	if forgotten {
+		session.dropped = true
+	}
+	session.mu.Unlock()
+
+	if forgotten {
+		m.mu.Lock()
+		if m.sessions[key] == session {
+			delete(m.sessions, key)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// drop removes the sessions that match from managedSessions and ends them.
+func (m *managedSessions) drop(ctx context.Context, match func(*managedSession) bool) {
+	m.mu.Lock()
+	var dropped []*managedSession
+	for key, session := range m.sessions {
+		if match(session) {
+			dropped = append(dropped, session)
+			delete(m.sessions, key)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, session := range dropped {
+		// waits out a session still being opened
+		session.mu.Lock()
+		session.dropped = true
+		id := session.id
+		session.mu.Unlock()
+		if id == "" {
+			continue
+		}
+		if err := m.end(ctx, id); err != nil && status.Code(err) != codes.Unimplemented {
+			log.Printf("failed to end managed MCP session %s: %v", id, err)
+		}
+	}
+}
+
+// expire ends the sessions that haven't been asked for in maxIdle.
+func (m *managedSessions) expire(ctx context.Context) {
+	cutoff := time.Now().Add(-m.maxIdle)
+	// lastUsed is guarded by m.mu, which drop holds while matching
+	m.drop(ctx, func(session *managedSession) bool { return session.lastUsed.Before(cutoff) })
+}
+
+func (m *managedSessions) run(ctx context.Context) {
+	defer close(m.done)
+	if m.maxIdle <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.maxIdle / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.expire(ctx)
+		}
+	}
+}
+
+// close stops expiring sessions and ends all of them.
+func (m *managedSessions) close() {
+	m.stop()
+	<-m.done
+	m.drop(context.Background(), func(*managedSession) bool { return true })
+}
+
+// ManageSessions has the proxy open and keep MCP sessions itself, so callers can skip
+// Initialize and leave out the mcp-session-id header. Sessions the server has expired
+// are opened again as needed, those no caller has used for maxIdle are ended, 0 for
+// never. It has to be called before starting, Close ends the sessions.
+func (s *Server) ManageSessions(maxIdle time.Duration) {
+	s.sessions = newManagedSessions(s.openSession, s.endManagedSession, maxIdle)
+}
+
+func (s *Server) endManagedSession(ctx context.Context, sessionID string) error {
+	s.roots.forget(sessionID)
+	return s.endSession(ctx, sessionID)
+}
+
+// openSession initializes a new session with the MCP server for the caller in ctx.
+func (s *Server) openSession(ctx context.Context) (string, error) {
+
+	// ctx may carry the session being replaced, which is no business of the new one
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		md = md.Copy()
+		md.Delete(mcpconst.MCP_SESSION_ID_HEADER)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	initializeRequest := &mcp.InitializeRequest{
+		ProtocolVersion: mcpconst.ProtocolVersion,
+		ClientInfo:      &mcp.Implementation{Name: "grpc2mcp"},
+	}
+	initializeResult, sessionID, err := s.doInitializeJsonRpc(ctx, initializeRequest)
+	if err != nil {
+		return "", status.Errorf(codes.Unavailable, "failed to initialize MCP session: %v", err)
+	}
+	s.batching.set(sessionID, initializeResult.GetProtocolVersion())
+	ctx = context.WithValue(ctx, mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+	if err := s.doInitializedJsonRpc(ctx); err != nil {
+		return "", status.Errorf(codes.Unavailable, "failed to ack MCP session initialization: %v", err)
+	}
+	return sessionID, nil
+}
+
+// withManagedSession fills in the caller's managed session when md has none.
+func (s *Server) withManagedSession(ctx context.Context, md metadata.MD) (context.Context, error) {
+
+	key := ""
+	if auth := md.Get(mcpconst.AuthorizationHeader); len(auth) > 0 {
+		key = auth[0]
+	}
+
+	sessionID, err := s.sessions.get(metadata.NewIncomingContext(ctx, md), key)
+	if err != nil {
+		return nil, err
+	}
+	md.Set(mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+	return context.WithValue(ctx, managedSessionKey{}, key), nil
+}
+
+// retryExpiredSession sends httpReq again in a new session when it was sent in a
+// managed one the MCP server no longer knows. ok is false if it wasn't retried.
+func (s *Server) retryExpiredSession(ctx context.Context, httpReq *http.Request) (*http.Request, bool) {
+
+	key, managed := ctx.Value(managedSessionKey{}).(string)
+	if s.sessions == nil || !managed || httpReq.GetBody == nil {
+		return nil, false
+	}
+
+	stale := httpReq.Header.Get(mcpconst.MCP_SESSION_ID_HEADER)
+	sessionID, err := s.sessions.renew(ctx, key, stale)
+	if err != nil {
+		log.Printf("failed to renew expired MCP session %s: %v", stale, err)
+		return nil, false
+	}
+	body, err := httpReq.GetBody()
+	if err != nil {
+		return nil, false
+	}
+
+	log.Printf("MCP session %s expired, continuing in %s", stale, sessionID)
+	// the caller's roots are theirs, not the expired session's
+	s.roots.move(stale, sessionID)
+	s.batching.forget(stale)
+	retryReq := httpReq.Clone(ctx)
+	retryReq.Body = body
+	retryReq.Header.Set(mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+	return retryReq, true
+}
+
+// withSessionID is ctx with its calls going to sessionID instead, eg once the session
+// it had expired and another took its place.
+func withSessionID(ctx context.Context, sessionID string) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		md = md.Copy()
+		md.Set(mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return context.WithValue(ctx, mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+}
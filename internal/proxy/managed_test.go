@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newBufconClient starts s, set up as the test needs, and returns a client for it
+func newBufconClient(t *testing.T, s *Server) pb.ModelContextProtocolClient {
+
+	lis := bufconn.Listen(1024 * 1024)
+	serverCancel, err := s.StartProxyToListenerAsync(lis)
+	require.NoError(t, err)
+	t.Cleanup(serverCancel)
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewModelContextProtocolClient(conn)
+}
+
+func newManagedBufconClient(t *testing.T, mcpUrl string) pb.ModelContextProtocolClient {
+	s, err := NewServer(mcpUrl)
+	require.NoError(t, err)
+	s.ManageSessions(0)
+	return newBufconClient(t, s)
+}
+
+func TestManagedSessions(t *testing.T) {
+
+	ts := httptest.NewServer(examplemcp.RunExampleMcpServer(t.Name(), "/mcp"))
+	defer ts.Close()
+
+	mcpGrpcClient := newManagedBufconClient(t, ts.URL)
+
+	// no Initialize and no mcp-session-id, the proxy takes care of it
+	_, err := mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoErrorf(t, err, "error with Ping")
+
+	listToolsResult, err := mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+	require.NoErrorf(t, err, "error with ListTools")
+	assert.NotEmpty(t, listToolsResult.GetTools())
+
+	// callers with their own session still get to use it
+	doGrpcProxyToolTests(t, mcpGrpcClient)
+
+	_, err = mcpGrpcClient.Terminate(t.Context(), &pb.TerminateRequest{})
+	require.NoErrorf(t, err, "error with Terminate")
+	_, err = mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoErrorf(t, err, "error with Ping after Terminate")
+}
+
+// the example server never expires a session, so a canned one that does stands in
+func TestManagedSessionsExpire(t *testing.T) {
+
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	opened := 0
+	live := map[string]string{} // session -> the authorization it was opened with
+	expire := func(session string) {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(live, session)
+	}
+
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var msg jsonrpc2.Request
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		mu.Lock()
+		defer mu.Unlock()
+		session := r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER)
+
+		switch mcpconst.JsonRpcMethod(msg.Method) {
+		case mcpconst.Initialize:
+			assert.Empty(session)
+			opened++
+			session = fmt.Sprintf("session-%d", opened)
+			live[session] = r.Header.Get(mcpconst.AuthorizationHeader)
+			w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, session)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"%s"}}`, msg.ID, mcpconst.ProtocolVersion)
+			return
+		case mcpconst.NotificationsInitialized:
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		auth, ok := live[session]
+		if !ok {
+			http.Error(w, "Session terminated", http.StatusNotFound)
+			return
+		}
+		assert.Equal(r.Header.Get(mcpconst.AuthorizationHeader), auth)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{}}`, msg.ID)
+	}))
+	defer mcpServer.Close()
+
+	mcpGrpcClient := newManagedBufconClient(t, mcpServer.URL)
+
+	_, err := mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoError(t, err)
+	_, err = mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoError(t, err)
+	assert.Equal(1, opened)
+
+	// a caller with its own credentials gets its own session
+	aliceCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.AuthorizationHeader, "Bearer alice")
+	_, err = mcpGrpcClient.Ping(aliceCtx, &pb.PingRequest{})
+	require.NoError(t, err)
+	assert.Equal(2, opened)
+
+	// once the server forgets a session the call goes through in a new one
+	expire("session-1")
+	_, err = mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoError(t, err)
+	assert.Equal(3, opened)
+	_, err = mcpGrpcClient.Ping(aliceCtx, &pb.PingRequest{})
+	require.NoError(t, err)
+	assert.Equal(3, opened)
+}
+
+func TestManagedSessionsMaxIdle(t *testing.T) {
+
+	assert := assert.New(t)
+
+	canned, mcpServer := newCannedSessions(t)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.ManageSessions(50 * time.Millisecond)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	aliceCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.AuthorizationHeader, "Bearer alice")
+	_, err = mcpGrpcClient.Ping(aliceCtx, &pb.PingRequest{})
+	require.NoError(t, err)
+
+	// alice goes quiet, so her session is ended with a DELETE and dropped
+	require.Eventually(t, func() bool {
+		canned.mu.Lock()
+		defer canned.mu.Unlock()
+		return slices.Contains(canned.ended, "session-1")
+	}, time.Second, 10*time.Millisecond)
+	s.sessions.mu.Lock()
+	assert.Empty(s.sessions.sessions)
+	s.sessions.mu.Unlock()
+
+	// and she gets a new one when she's back
+	_, err = mcpGrpcClient.Ping(aliceCtx, &pb.PingRequest{})
+	require.NoError(t, err)
+	opened, live, _ := canned.stats()
+	assert.Equal(2, opened)
+	assert.Equal(1, live)
+
+	// the proxy ends the sessions it still holds when it's closed
+	require.NoError(t, s.Close())
+	_, live, _ = canned.stats()
+	assert.Zero(live)
+}
+
+func TestManagedSessionsTerminate(t *testing.T) {
+
+	canned, mcpServer := newCannedSessions(t)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.ManageSessions(0)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	_, err = mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoError(t, err)
+	_, err = mcpGrpcClient.Terminate(t.Context(), &pb.TerminateRequest{})
+	require.NoError(t, err)
+
+	// a terminated session leaves nothing behind
+	s.sessions.mu.Lock()
+	assert.Empty(t, s.sessions.sessions)
+	s.sessions.mu.Unlock()
+	_, live, _ := canned.stats()
+	assert.Zero(t, live)
+}
@@ -6,40 +6,61 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 
 	"grpc2mcp/internal/jsonrpc"
 	"grpc2mcp/internal/mcpconst"
 	"grpc2mcp/pb"
 	mcp "grpc2mcp/pb"
 
+	"github.com/sourcegraph/jsonrpc2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-// initialize sends the 'initialize' request and synchronously parses the SSE response to get a session ID.
-func (s *Server) doInitializeJsonRpc(ctx context.Context, req *mcp.InitializeRequest) (string, error) {
+// initialize sends the 'initialize' request and synchronously parses the SSE response to get
+// both the server's InitializeResult and a session ID.
+func (s *Server) doInitializeJsonRpc(ctx context.Context, req *mcp.InitializeRequest) (*mcp.InitializeResult, string, error) {
 	log.Println("Initializing MCP session...")
 
 	additionalHeaders := initHttpHeadersFromContext(ctx)
 	httpReq, err := jsonrpc.NewJSONRPCRequest(ctx, s.mcpUrl, mcpconst.Initialize, req, additionalHeaders, http.NewRequestWithContext)
 	if err != nil {
-		return "", status.Errorf(codes.Internal, "failed 'initialize' jsonrpc request: %v", err)
+		return nil, "", status.Errorf(codes.Internal, "failed 'initialize' jsonrpc request: %v", err)
 	}
 
-	_, httpResp, err := jsonrpc.DoRequest(ctx, &s.httpClient, httpReq)
+	// initialize must never be cancelled, so it isn't tracked like everything else
+	resp, httpResp, err := jsonrpc.DoRequest(ctx, &s.httpClient, httpReq)
 	if err != nil {
-		return "", err // DoRequest already wraps the error.
+		return nil, "", err // DoRequest already wraps the error.
+	}
+
+	if resp == nil {
+		return nil, "", fmt.Errorf("MCP server returned an empty 'initialize' response")
+	}
+
+	if resp.Error != nil {
+		return nil, "", fmt.Errorf("MCP server returned an error (code %d): %s", resp.Error.Code, resp.Error.Message)
+	}
+
+	if resp.Result == nil {
+		return nil, "", fmt.Errorf("MCP server returned a nil 'initialize' result")
+	}
+
+	var initializeResult mcp.InitializeResult
+	if err := json.Unmarshal(*resp.Result, &initializeResult); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal 'initialize' result: %w", err)
 	}
 
 	mcpSessionId, ok := httpResp.Header[http.CanonicalHeaderKey(mcpconst.MCP_SESSION_ID_HEADER)]
 	if !ok || len(mcpSessionId) < 1 {
-		return "", fmt.Errorf("did not find MCP Session ID header: %s", mcpconst.MCP_SESSION_ID_HEADER)
+		return nil, "", fmt.Errorf("did not find MCP Session ID header: %s", mcpconst.MCP_SESSION_ID_HEADER)
 	}
 
-	return mcpSessionId[0], nil
+	return &initializeResult, mcpSessionId[0], nil
 }
 
 // follows up initialize() with an initialized() (notice the past tense) call to confirm a session
@@ -52,7 +73,7 @@ func (s *Server) doInitializedJsonRpc(ctx context.Context) error {
 		return status.Errorf(codes.Internal, "failed 'initialized' http request: %v", err)
 	}
 
-	_, _, err = jsonrpc.DoRequest(ctx, &s.httpClient, httpReq)
+	_, _, err = s.doRequest(ctx, httpReq, nil)
 	return err
 }
 
@@ -60,10 +81,22 @@ func (s *Server) doInitializedJsonRpc(ctx context.Context) error {
 func (s *Server) Initialize(ctx context.Context, req *mcp.InitializeRequest) (*mcp.InitializeResult, error) {
 	log.Println("Initialize called...")
 
-	sessionID, err := s.doInitializeJsonRpc(ctx, req)
+	roots := req.GetRoots()
+	if len(roots) > 0 {
+		if err := validateRoots(roots); err != nil {
+			return nil, err
+		}
+		req = withoutRoots(req)
+	}
+
+	initializeResult, sessionID, err := s.doInitializeJsonRpc(ctx, req)
 	if err != nil || sessionID == "" {
 		return nil, status.Errorf(codes.Internal, "failed to initialize MCP session: %v", err)
 	}
+	if len(roots) > 0 {
+		s.roots.set(sessionID, roots)
+	}
+	s.batching.set(sessionID, initializeResult.GetProtocolVersion())
 
 	// tuck the sessionId into the ctx for the subsequent Initialized ack call
 	ctx = context.WithValue(ctx, mcpconst.MCP_SESSION_ID_HEADER, sessionID)
@@ -79,8 +112,7 @@ func (s *Server) Initialize(ctx context.Context, req *mcp.InitializeRequest) (*m
 
 	log.Printf("Initialize and Initiailized complete for: %s", sessionID)
 
-	// TODO: This should be a real response from the server
-	return &mcp.InitializeResult{}, nil
+	return initializeResult, nil
 }
 
 // CallMethod implements the CallMethod RPC.
@@ -97,11 +129,17 @@ func (s *Server) doCallMethodRpc(ctx context.Context, req *mcp.CallToolRequest)
 		return nil, status.Errorf(codes.Internal, "failed to create http request for %s: %v", mcpconst.ToolsCall, err)
 	}
 
-	resp, _, err := jsonrpc.DoRequest(ctx, &s.httpClient, httpReq)
+	resp, _, err := s.doRequest(ctx, httpReq, nil)
 	if err != nil {
 		return nil, err // DoRequest already wraps the error.
 	}
 
+	return decodeCallToolResult(resp)
+}
+
+// decodeCallToolResult checks the tools/call response and decodes its result.
+func decodeCallToolResult(resp *jsonrpc2.Response) (*mcp.CallToolResult, error) {
+
 	if resp == nil {
 		return nil, status.Errorf(codes.Internal, "MCP server returned a nil response")
 	}
@@ -130,53 +168,153 @@ func (s *Server) doCallMethodRpc(ctx context.Context, req *mcp.CallToolRequest)
 		IsError: &rawResult.IsError,
 	}
 
-	for _, rawContent := range rawResult.Content {
-		var typeProbe struct {
-			Type string `json:"type"`
-		}
-		if err := json.Unmarshal(rawContent, &typeProbe); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to probe content type: %v", err)
+	if len(rawResult.StructuredContent) > 0 && string(rawResult.StructuredContent) != "null" {
+		var structuredContent structpb.Struct
+		if err := json.Unmarshal(rawResult.StructuredContent, &structuredContent); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal structuredContent: %v", err)
 		}
+		finalResult.StructuredContent = &structuredContent
+	}
 
-		var contentBlock mcp.ContentBlock
-		switch typeProbe.Type {
-		case "text":
-			var textContent mcp.TextContent
-			if err := json.Unmarshal(rawContent, &textContent); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to unmarshal TextContent: %v", err)
-			}
-			contentBlock.ContentType = &mcp.ContentBlock_Text{Text: &textContent}
-		case "resource_link":
-			var resource mcp.Resource
-			if err := json.Unmarshal(rawContent, &resource); err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to unmarshal ResourceLink: %v", err)
-			}
-			resourceLink := pb.ResourceLink{Type: typeProbe.Type, Resource: &resource}
-			contentBlock.ContentType = &mcp.ContentBlock_ResourceLink{ResourceLink: &resourceLink}
-		// TODO: Add cases for ImageContent, AudioContent, etc. as needed
-		default:
-			log.Printf("unknown content type: %s", typeProbe.Type)
+	for _, rawContent := range rawResult.Content {
+		contentBlock, err := decodeContentBlock(rawContent)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		if contentBlock == nil {
 			continue
 		}
-		finalResult.Content = append(finalResult.Content, &contentBlock)
+		finalResult.Content = append(finalResult.Content, contentBlock)
 	}
 
 	return finalResult, nil
 }
 
+// decodeContentBlock unmarshals one polymorphic content block based on its "type". Types
+// we don't know about are logged and come back nil so callers can skip them.
+func decodeContentBlock(rawContent json.RawMessage) (*mcp.ContentBlock, error) {
+	var typeProbe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(rawContent, &typeProbe); err != nil {
+		return nil, fmt.Errorf("failed to probe content type: %w", err)
+	}
+
+	var contentBlock mcp.ContentBlock
+	switch typeProbe.Type {
+	case "text":
+		var textContent mcp.TextContent
+		if err := json.Unmarshal(rawContent, &textContent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal TextContent: %w", err)
+		}
+		contentBlock.ContentType = &mcp.ContentBlock_Text{Text: &textContent}
+	case "image":
+		// data is base64 on the wire, encoding/json decodes it into the bytes field
+		var imageContent mcp.ImageContent
+		if err := json.Unmarshal(rawContent, &imageContent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ImageContent: %w", err)
+		}
+		contentBlock.ContentType = &mcp.ContentBlock_Image{Image: &imageContent}
+	case "audio":
+		var audioContent mcp.AudioContent
+		if err := json.Unmarshal(rawContent, &audioContent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal AudioContent: %w", err)
+		}
+		contentBlock.ContentType = &mcp.ContentBlock_Audio{Audio: &audioContent}
+	case "resource_link":
+		var resource mcp.Resource
+		if err := json.Unmarshal(rawContent, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ResourceLink: %w", err)
+		}
+		resourceLink := pb.ResourceLink{Type: typeProbe.Type, Resource: &resource}
+		contentBlock.ContentType = &mcp.ContentBlock_ResourceLink{ResourceLink: &resourceLink}
+	case "resource":
+		embeddedResource, err := decodeEmbeddedResource(rawContent)
+		if err != nil {
+			return nil, err
+		}
+		contentBlock.ContentType = &mcp.ContentBlock_EmbeddedResource{EmbeddedResource: embeddedResource}
+	default:
+		log.Printf("unknown content type: %s", typeProbe.Type)
+		return nil, nil
+	}
+
+	return &contentBlock, nil
+}
+
+// decodeEmbeddedResource unmarshals the envelope of an embedded resource and then its
+// "resource", which is either text or blob contents.
+func decodeEmbeddedResource(rawContent json.RawMessage) (*mcp.EmbeddedResource, error) {
+	var embeddedResource mcp.EmbeddedResource
+	if err := json.Unmarshal(rawContent, &embeddedResource); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal EmbeddedResource: %w", err)
+	}
+
+	var resourceProbe struct {
+		Resource json.RawMessage `json:"resource"`
+	}
+	if err := json.Unmarshal(rawContent, &resourceProbe); err != nil || len(resourceProbe.Resource) == 0 {
+		return nil, fmt.Errorf("EmbeddedResource is missing its resource: %v", err)
+	}
+
+	resourceContents, err := decodeResourceContents(resourceProbe.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	switch contents := resourceContents.ContentsType.(type) {
+	case *mcp.ResourceContents_Text:
+		embeddedResource.ResourceContents = &mcp.EmbeddedResource_TextResource{TextResource: contents.Text}
+	case *mcp.ResourceContents_Blob:
+		embeddedResource.ResourceContents = &mcp.EmbeddedResource_BlobResource{BlobResource: contents.Blob}
+	}
+
+	return &embeddedResource, nil
+}
+
 // ListTools implements the ListTools RPC.
 func (s *Server) ListTools(ctx context.Context, req *mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
 	var listToolsResult mcp.ListToolsResult
-	err := s.doRpcCall(ctx, req, "tools/list", &listToolsResult)
+	err := s.doRpcCall(ctx, req, mcpconst.ToolsList, &listToolsResult)
 	return &listToolsResult, err
 }
 
+// Complete implements the Complete RPC. The ref oneof doesn't marshal to the spec's
+// shape on its own, so the params are put together here.
 func (s *Server) Complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	ref, err := completionRef(req.GetRef())
+	if err != nil {
+		return nil, err
+	}
+
+	// name and value are both required, even when value is still empty
+	params := struct {
+		Ref      any `json:"ref"`
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+		Context *mcp.CompletionContext `json:"context,omitempty"`
+	}{Ref: ref, Context: req.GetContext()}
+	params.Argument.Name = req.GetArgument().GetName()
+	params.Argument.Value = req.GetArgument().GetValue()
+
 	var result mcp.CompleteResult
-	err := s.doRpcCall(ctx, req, "completion/complete", &result)
+	err = s.doRpcCall(ctx, params, mcpconst.CompletionComplete, &result)
 	return &result, err
 }
 
+// completionRef turns the Reference oneof into a ref/prompt or ref/resource reference.
+func completionRef(ref *mcp.Reference) (any, error) {
+	switch ref := ref.GetRefOneof().(type) {
+	case *mcp.Reference_Prompt:
+		return map[string]string{"type": mcpconst.RefPrompt, "name": ref.Prompt.GetName()}, nil
+	case *mcp.Reference_ResourceTemplate:
+		return map[string]string{"type": mcpconst.RefResource, "uri": ref.ResourceTemplate.GetUri()}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "complete needs a prompt or resourceTemplate ref")
+}
+
 func (s *Server) Ping(ctx context.Context, req *mcp.PingRequest) (*mcp.PingResult, error) {
 	var result mcp.PingResult
 	err := s.doRpcCall(ctx, req, mcpconst.Ping, &result)
@@ -192,9 +330,35 @@ func (s *Server) ListPrompts(ctx context.Context, req *mcp.ListPromptsRequest) (
 
 // GetPrompt implements the GetPrompt RPC.
 func (s *Server) GetPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	var result mcp.GetPromptResult
-	err := s.doRpcCall(ctx, req, "prompts/get", &result)
-	return &result, err
+	// message content is polymorphic, so take it raw and decode each one below
+	var rawResult struct {
+		Description *string `json:"description"`
+		Messages    []struct {
+			Role    string          `json:"role"`
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+		Meta *structpb.Struct `json:"_meta"`
+	}
+	if err := s.doRpcCall(ctx, req, mcpconst.PromptsGet, &rawResult); err != nil {
+		return nil, err
+	}
+
+	result := &mcp.GetPromptResult{Description: rawResult.Description, XMeta: rawResult.Meta}
+	for _, rawMessage := range rawResult.Messages {
+		contentBlock, err := decodeContentBlock(rawMessage.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode prompt message: %v", err)
+		}
+		if contentBlock == nil {
+			continue
+		}
+		result.Messages = append(result.Messages, &mcp.PromptMessage{
+			Role:    mcp.Role(mcp.Role_value[strings.ToUpper(rawMessage.Role)]),
+			Content: contentBlock,
+		})
+	}
+
+	return result, nil
 }
 
 // ListResources implements the ListResources RPC.
@@ -211,8 +375,57 @@ func (s *Server) ListResourceTemplates(ctx context.Context, req *mcp.ListResourc
 	return &result, err
 }
 
+// ReadResource implements the ReadResource RPC.
+func (s *Server) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	// contents are polymorphic, so take them raw and decode each one below
+	var rawResult struct {
+		Contents []json.RawMessage `json:"contents"`
+		Meta     *structpb.Struct  `json:"_meta"`
+	}
+	if err := s.doRpcCall(ctx, req, mcpconst.ResourcesRead, &rawResult); err != nil {
+		return nil, err
+	}
+
+	result := &mcp.ReadResourceResult{XMeta: rawResult.Meta}
+	for _, rawContents := range rawResult.Contents {
+		contents, err := decodeResourceContents(rawContents)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to decode resource contents: %v", err)
+		}
+		result.Contents = append(result.Contents, contents)
+	}
+
+	return result, nil
+}
+
+// decodeResourceContents tells text from blob resource contents by which field
+// is present. blobs come over the wire base64 encoded, which encoding/json
+// decodes for us into the proto bytes field.
+func decodeResourceContents(rawContents json.RawMessage) (*mcp.ResourceContents, error) {
+	var fieldProbe struct {
+		Blob *string `json:"blob"`
+	}
+	if err := json.Unmarshal(rawContents, &fieldProbe); err != nil {
+		return nil, fmt.Errorf("failed to probe resource contents: %w", err)
+	}
+
+	if fieldProbe.Blob != nil {
+		var blobContents mcp.BlobResourceContents
+		if err := json.Unmarshal(rawContents, &blobContents); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BlobResourceContents: %w", err)
+		}
+		return &mcp.ResourceContents{ContentsType: &mcp.ResourceContents_Blob{Blob: &blobContents}}, nil
+	}
+
+	var textContents mcp.TextResourceContents
+	if err := json.Unmarshal(rawContents, &textContents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TextResourceContents: %w", err)
+	}
+	return &mcp.ResourceContents{ContentsType: &mcp.ResourceContents_Text{Text: &textContents}}, nil
+}
+
 // This is the heart of doing a session jsonrpc call and unpacking, then deserializing the result.
-func (s *Server) doRpcCall(ctx context.Context, req protoreflect.ProtoMessage,
+func (s *Server) doRpcCall(ctx context.Context, req any,
 	jsonRpcMethod mcpconst.JsonRpcMethod, rpcResultPtr any) error {
 
 	additionalHeaders := initHttpHeadersFromContext(ctx)
@@ -221,7 +434,7 @@ func (s *Server) doRpcCall(ctx context.Context, req protoreflect.ProtoMessage,
 		return status.Errorf(codes.Internal, "failed to create http request for %s: %v", jsonRpcMethod, err)
 	}
 
-	resp, _, err := jsonrpc.DoRequest(ctx, &s.httpClient, httpReq)
+	resp, _, err := s.doRequest(ctx, httpReq, nil)
 	if err != nil {
 		return err // DoRequest already wraps the error.
 	}
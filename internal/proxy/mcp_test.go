@@ -0,0 +1,38 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"grpc2mcp/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mcp-go drops structuredContent when it marshals a CallToolResult, so we check
+// our decoding of it against a canned response instead of the example server.
+func TestCallMethodStructuredContent(t *testing.T) {
+
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{
+			"content":[{"type":"text","text":"{\"sum\":3}"}],
+			"structuredContent":{"sum":3,"operands":[1,2]}}}`))
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+
+	callToolResult, err := s.CallMethod(t.Context(), &pb.CallToolRequest{Name: "add"})
+	require.NoError(t, err)
+
+	require.Len(t, callToolResult.GetContent(), 1)
+	assert.Equal(t, `{"sum":3}`, callToolResult.GetContent()[0].GetText().GetText())
+
+	require.NotNil(t, callToolResult.GetStructuredContent())
+	assert.Equal(t, map[string]any{"sum": 3.0, "operands": []any{1.0, 2.0}},
+		callToolResult.GetStructuredContent().AsMap())
+}
@@ -0,0 +1,237 @@
+package proxy
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PoolOptions configures the pool of warm MCP sessions.
+type PoolOptions struct {
+	// MinSize is how many idle sessions the pool keeps initialized and ready
+	MinSize int
+	// MaxSize is the most idle sessions the pool holds on to, those released beyond it
+	// are ended. 0 for no limit, anything under MinSize counts as MinSize
+	MaxSize int
+	// MaxIdle is how long a session may sit unused before it's ended, 0 for no limit
+	MaxIdle time.Duration
+	// HealthCheckInterval is how often idle sessions are pinged, pruned and topped up
+	HealthCheckInterval time.Duration
+}
+
+const defaultHealthCheckInterval = 30 * time.Second
+
+// sessionPool holds initialized sessions for anonymous callers to borrow one call at a
+// time, so they don't pay for initialize and notifications/initialized themselves.
+type sessionPool struct {
+	opts PoolOptions
+	open func(ctx context.Context) (string, error)
+	ping func(ctx context.Context, sessionID string) error
+	end  func(ctx context.Context, sessionID string) error
+	// reset clears what the proxy keeps for a session, so none of it outlives the borrow
+	reset func(sessionID string)
+
+	mu      sync.Mutex
+	idle    []pooledSession
+	filling bool
+	// fillers is the filler at work, which close waits on
+	fillers sync.WaitGroup
+	closed  bool
+	stop    context.CancelFunc
+	done    chan struct{}
+}
+
+type pooledSession struct {
+	id        string
+	idleSince time.Time
+}
+
+// borrow hands out an idle session, or a new one if there are none.
+func (p *sessionPool) borrow(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		session := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		p.fill()
+		return session.id, nil
+	}
+	p.mu.Unlock()
+
+	p.fill()
+	return p.open(ctx)
+}
+
+// release gives back a borrowed session, or ends it if it's no longer fit to reuse or
+// the pool is already full.
+func (p *sessionPool) release(sessionID string, reuse bool) {
+	p.reset(sessionID)
+
+	p.mu.Lock()
+	if reuse && !p.closed && !p.full() {
+		p.idle = append(p.idle, pooledSession{id: sessionID, idleSince: time.Now()})
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+
+	if err := p.end(context.Background(), sessionID); err != nil && status.Code(err) != codes.Unimplemented {
+		log.Printf("failed to end pooled MCP session %s: %v", sessionID, err)
+	}
+}
+
+// full is whether there's no room for another idle session, p.mu has to be held.
+func (p *sessionPool) full() bool {
+	return p.opts.MaxSize > 0 && len(p.idle) >= max(p.opts.MaxSize, p.opts.MinSize)
+}
+
+// fill tops the pool up to MinSize in the background, one filler at a time.
+func (p *sessionPool) fill() {
+	p.mu.Lock()
+	if p.filling || p.closed || len(p.idle) >= p.opts.MinSize {
+		p.mu.Unlock()
+		return
+	}
+	p.filling = true
+	p.fillers.Add(1)
+	p.mu.Unlock()
+
+	go func() {
+		defer p.fillers.Done()
+		defer func() {
+			p.mu.Lock()
+			p.filling = false
+			p.mu.Unlock()
+		}()
+		for {
+			p.mu.Lock()
+			full := p.closed || len(p.idle) >= p.opts.MinSize
+			p.mu.Unlock()
+			if full {
+				return
+			}
+
+			sessionID, err := p.open(context.Background())
+			if err != nil {
+				log.Printf("failed to open pooled MCP session: %v", err)
+				return
+			}
+			p.release(sessionID, true)
+		}
+	}()
+}
+
+// check pings the idle sessions, ending those that fail or have been idle too long,
+// then tops the pool back up. Sessions stay up for borrowing while they're checked.
+func (p *sessionPool) check(ctx context.Context) {
+	p.mu.Lock()
+	idle := slices.Clone(p.idle)
+	p.mu.Unlock()
+
+	for _, session := range idle {
+		expired := p.opts.MaxIdle > 0 && time.Since(session.idleSince) > p.opts.MaxIdle
+		if !expired {
+			err := p.ping(ctx, session.id)
+			if err == nil {
+				continue
+			}
+			log.Printf("pooled MCP session %s failed its health check: %v", session.id, err)
+		}
+		if p.take(session.id) {
+			p.release(session.id, false)
+		}
+	}
+
+	p.fill()
+}
+
+// take removes sessionID from the idle sessions, false if it's been borrowed since.
+func (p *sessionPool) take(sessionID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := slices.IndexFunc(p.idle, func(session pooledSession) bool { return session.id == sessionID })
+	if i < 0 {
+		return false
+	}
+	p.idle = slices.Delete(p.idle, i, i+1)
+	return true
+}
+
+func (p *sessionPool) run(ctx context.Context) {
+	defer close(p.done)
+
+	interval := p.opts.HealthCheckInterval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	p.fill()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.check(ctx)
+		}
+	}
+}
+
+// close stops the health checks and ends the idle sessions, along with any a filler
+// is still opening.
+func (p *sessionPool) close() {
+	p.stop()
+	<-p.done
+
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	// once closed, a filler ends what it opens rather than pooling it
+	p.fillers.Wait()
+
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, session := range idle {
+		p.release(session.id, false)
+	}
+}
+
+// PoolSessions has the proxy keep a pool of initialized MCP sessions, which callers
+// sending neither an mcp-session-id nor an authorization header borrow for the length
+// of each call. It has to be called before starting, Close stops it.
+func (s *Server) PoolSessions(opts PoolOptions) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.pool = &sessionPool{
+		opts:  opts,
+		open:  s.openSession,
+		ping:  s.pingSession,
+		end:   s.endSession,
+		reset: s.roots.forget,
+		stop:  cancel,
+		done:  make(chan struct{}),
+	}
+	go s.pool.run(ctx)
+}
+
+func (s *Server) pingSession(ctx context.Context, sessionID string) error {
+	ctx = context.WithValue(ctx, mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+	var result mcp.PingResult
+	return s.doRpcCall(ctx, &mcp.PingRequest{}, mcpconst.Ping, &result)
+}
+
+func (s *Server) endSession(ctx context.Context, sessionID string) error {
+	s.batching.forget(sessionID)
+	return s.terminateSession(context.WithValue(ctx, mcpconst.MCP_SESSION_ID_HEADER, sessionID))
+}
@@ -0,0 +1,241 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestPooledSessions(t *testing.T) {
+
+	ts := httptest.NewServer(examplemcp.RunExampleMcpServer(t.Name(), "/mcp"))
+	defer ts.Close()
+
+	s, err := NewServer(ts.URL)
+	require.NoError(t, err)
+	s.PoolSessions(PoolOptions{MinSize: 2})
+	defer s.Close()
+	mcpGrpcClient := newBufconClient(t, s)
+
+	// no Initialize and no mcp-session-id, unary and streaming calls alike
+	_, err = mcpGrpcClient.Ping(t.Context(), &pb.PingRequest{})
+	require.NoErrorf(t, err, "error with Ping")
+	doGrpcProxyToolTests(t, mcpGrpcClient)
+
+	stream, err := mcpGrpcClient.CallMethodStream(t.Context())
+	require.NoError(t, err)
+	callToolRequest, err := toolTestData[0].NewToolRequest()
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(callToolRequest))
+	require.NoError(t, stream.CloseSend())
+	callToolResult, err := stream.Recv()
+	require.NoError(t, err)
+	validateCallToolResult(t, callToolResult, toolTestData[0])
+}
+
+// cannedSessions is an MCP server that keeps track of its sessions and what's asked of
+// them, and that can be told to forget one
+type cannedSessions struct {
+	mu     sync.Mutex
+	opened int
+	live   map[string]bool
+	pings  int
+	ended  []string
+}
+
+func newCannedSessions(t *testing.T) (*cannedSessions, *httptest.Server) {
+	c := &cannedSessions{live: map[string]bool{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		session := r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER)
+
+		if r.Method == http.MethodDelete {
+			delete(c.live, session)
+			c.ended = append(c.ended, session)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var msg jsonrpc2.Request
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		switch mcpconst.JsonRpcMethod(msg.Method) {
+		case mcpconst.Initialize:
+			c.opened++
+			session = fmt.Sprintf("session-%d", c.opened)
+			c.live[session] = true
+			w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, session)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"%s"}}`, msg.ID, mcpconst.ProtocolVersion)
+			return
+		case mcpconst.NotificationsInitialized:
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		if !c.live[session] {
+			http.Error(w, "Session terminated", http.StatusNotFound)
+			return
+		}
+		if mcpconst.JsonRpcMethod(msg.Method) == mcpconst.Ping {
+			c.pings++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"tools":[]}}`, msg.ID)
+	}))
+	return c, server
+}
+
+func (c *cannedSessions) stats() (opened, live, pings int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opened, len(c.live), c.pings
+}
+
+func TestSessionPool(t *testing.T) {
+
+	assert := assert.New(t)
+
+	canned, mcpServer := newCannedSessions(t)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.PoolSessions(PoolOptions{MinSize: 2, HealthCheckInterval: 10 * time.Millisecond})
+	mcpGrpcClient := newBufconClient(t, s)
+
+	// the pool warms up without anyone asking
+	require.Eventually(t, func() bool {
+		_, live, pings := canned.stats()
+		return live == 2 && pings > 0
+	}, time.Second, 5*time.Millisecond)
+
+	// calls are served from the warm sessions. the first borrow has the pool top itself
+	// back up in the background, after that there's enough idle to go round
+	_, err = mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, live, _ := canned.stats()
+		return live == 3
+	}, time.Second, 5*time.Millisecond)
+	for range 5 {
+		_, err := mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+		require.NoError(t, err)
+	}
+	opened, live, _ := canned.stats()
+	assert.Equal(3, opened)
+	assert.Equal(3, live)
+
+	// callers with a session or credentials of their own don't touch the pool
+	aliceCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.AuthorizationHeader, "Bearer alice")
+	_, err = mcpGrpcClient.ListTools(aliceCtx, &pb.ListToolsRequest{})
+	assert.Error(err)
+
+	// a session the server forgets fails its health check and is dropped
+	canned.mu.Lock()
+	delete(canned.live, "session-1")
+	canned.mu.Unlock()
+	require.Eventually(t, func() bool {
+		canned.mu.Lock()
+		defer canned.mu.Unlock()
+		return slices.Contains(canned.ended, "session-1")
+	}, time.Second, 5*time.Millisecond)
+	for range 5 {
+		_, err := mcpGrpcClient.ListTools(t.Context(), &pb.ListToolsRequest{})
+		require.NoError(t, err)
+	}
+
+	// closing ends what's left
+	require.NoError(t, s.Close())
+	_, live, _ = canned.stats()
+	assert.Zero(live)
+}
+
+func TestSessionPoolMaxIdle(t *testing.T) {
+
+	canned, mcpServer := newCannedSessions(t)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.PoolSessions(PoolOptions{MinSize: 1, MaxIdle: 20 * time.Millisecond, HealthCheckInterval: 10 * time.Millisecond})
+	defer s.Close()
+
+	// an idle session is ended and a fresh one takes its place
+	require.Eventually(t, func() bool {
+		canned.mu.Lock()
+		defer canned.mu.Unlock()
+		return len(canned.ended) > 0 && len(canned.live) == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSessionPoolMaxSize(t *testing.T) {
+
+	canned, mcpServer := newCannedSessions(t)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.PoolSessions(PoolOptions{MinSize: 1, MaxSize: 2})
+	defer s.Close()
+	require.Eventually(t, func() bool {
+		_, live, _ := canned.stats()
+		return live == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// a burst borrows more sessions than the pool holds, the extra ones end once returned
+	var borrowed []string
+	for range 4 {
+		sessionID, err := s.pool.borrow(t.Context())
+		require.NoError(t, err)
+		borrowed = append(borrowed, sessionID)
+	}
+	for _, sessionID := range borrowed {
+		s.pool.release(sessionID, true)
+	}
+
+	s.pool.mu.Lock()
+	idle := len(s.pool.idle)
+	s.pool.mu.Unlock()
+	assert.Equal(t, 2, idle)
+	require.Eventually(t, func() bool {
+		_, live, _ := canned.stats()
+		return live == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSessionPoolForgetsRoots(t *testing.T) {
+
+	_, mcpServer := newCannedSessions(t)
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+	s.PoolSessions(PoolOptions{MinSize: 1})
+	defer s.Close()
+	mcpGrpcClient := newBufconClient(t, s)
+
+	// roots set in a borrowed session are the borrower's, the next one mustn't see them
+	_, err = mcpGrpcClient.SetRoots(t.Context(), &pb.SetRootsRequest{Roots: []*pb.Root{{Uri: "file:///home/alice"}}})
+	require.NoError(t, err)
+
+	s.roots.mu.Lock()
+	defer s.roots.mu.Unlock()
+	assert.Empty(t, s.roots.bySession)
+}
@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CallToolWithProgress calls a tool with a progressToken in its _meta, streaming back
+// the progress and log notifications the server sends while the tool runs, and then
+// the result itself. A progressToken already in the request is kept.
+func (s *Server) CallToolWithProgress(req *mcp.CallToolRequest, stream mcp.ModelContextProtocol_CallToolWithProgressServer) error {
+	ctx := stream.Context()
+
+	req = proto.Clone(req).(*mcp.CallToolRequest)
+	if req.XMeta == nil {
+		req.XMeta = &structpb.Struct{}
+	}
+	if req.XMeta.Fields == nil {
+		req.XMeta.Fields = map[string]*structpb.Value{}
+	}
+	if _, ok := req.XMeta.Fields[mcpconst.ProgressTokenKey]; !ok {
+		req.XMeta.Fields[mcpconst.ProgressTokenKey] = structpb.NewStringValue(fmt.Sprintf("grpc2mcp-%d", rand.Int63()))
+	}
+
+	additionalHeaders := initHttpHeadersFromContext(ctx)
+	httpReq, err := jsonrpc.NewJSONRPCRequest(ctx, s.mcpUrl, mcpconst.ToolsCall, req, additionalHeaders, http.NewRequestWithContext)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create http request for %s: %v", mcpconst.ToolsCall, err)
+	}
+
+	resp, _, err := s.doRequest(ctx, httpReq, func(msg *jsonrpc2.Request) error {
+		event, err := decodeProgressEvent(msg)
+		if err != nil || event == nil {
+			return err
+		}
+		return stream.Send(event)
+	})
+	if err != nil {
+		return err // doRequest already wraps the error.
+	}
+
+	result, err := decodeCallToolResult(resp)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&mcp.CallToolProgress{Event: &mcp.CallToolProgress_Result{Result: result}})
+}
+
+// decodeProgressEvent turns progress and log notifications into stream events. Anything
+// else comes back nil, to be skipped.
+func decodeProgressEvent(msg *jsonrpc2.Request) (*mcp.CallToolProgress, error) {
+
+	if msg.Params == nil {
+		return nil, nil
+	}
+
+	switch mcpconst.JsonRpcMethod(msg.Method) {
+	case mcpconst.NotificationsProgress:
+		var progress mcp.ProgressNotification
+		if err := json.Unmarshal(*msg.Params, &progress); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal progress notification: %v", err)
+		}
+		return &mcp.CallToolProgress{Event: &mcp.CallToolProgress_Progress{Progress: &progress}}, nil
+	case mcpconst.NotificationsMessage:
+		logMessage, err := decodeLoggingMessage(*msg.Params)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CallToolProgress{Event: &mcp.CallToolProgress_Log{Log: logMessage}}, nil
+	}
+	return nil, nil
+}
+
+// decodeLoggingMessage decodes the params of a notifications/message. Levels come as
+// lower case names on the wire, which encoding/json can't map onto the enum for us.
+func decodeLoggingMessage(params json.RawMessage) (*mcp.LoggingMessageNotification, error) {
+	var rawMessage struct {
+		Level  string          `json:"level"`
+		Logger *string         `json:"logger"`
+		Data   *structpb.Value `json:"data"`
+	}
+	if err := json.Unmarshal(params, &rawMessage); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal log message: %v", err)
+	}
+	return &mcp.LoggingMessageNotification{
+		Level:  mcp.LoggingLevel(mcp.LoggingLevel_value[strings.ToUpper(rawMessage.Level)]),
+		Logger: rawMessage.Logger,
+		Data:   rawMessage.Data,
+	}, nil
+}
@@ -0,0 +1,11 @@
+package proxy
+
+// DefaultMaxStreamResumes is how many times a call's SSE response is resumed after the
+// connection to the MCP server drops before the call fails.
+const DefaultMaxStreamResumes = 3
+
+// ResumeStreams sets how many times a call's SSE response is resumed, 0 to never
+// resume. Servers start out with DefaultMaxStreamResumes.
+func (s *Server) ResumeStreams(maxResumes int) {
+	s.maxResumes = max(maxResumes, 0)
+}
@@ -0,0 +1,121 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// sessionRoots keeps the roots each session's client declared, which the proxy answers
+// the server's roots/list with.
+type sessionRoots struct {
+	mu        sync.Mutex
+	bySession map[string][]*mcp.Root
+}
+
+func (sr *sessionRoots) set(sessionID string, roots []*mcp.Root) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sr.bySession == nil {
+		sr.bySession = map[string][]*mcp.Root{}
+	}
+	sr.bySession[sessionID] = roots
+}
+
+func (sr *sessionRoots) get(sessionID string) []*mcp.Root {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	return sr.bySession[sessionID]
+}
+
+func (sr *sessionRoots) forget(sessionID string) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	delete(sr.bySession, sessionID)
+}
+
+// move hands from's roots on to to, the session replacing it.
+func (sr *sessionRoots) move(from, to string) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	roots, ok := sr.bySession[from]
+	if !ok {
+		return
+	}
+	delete(sr.bySession, from)
+	sr.bySession[to] = roots
+}
+
+// SetRoots implements the SetRoots RPC. It replaces the session's roots and sends the
+// server notifications/roots/list_changed so it asks for them again.
+func (s *Server) SetRoots(ctx context.Context, req *mcp.SetRootsRequest) (*mcp.SetRootsResult, error) {
+	sessionID := sessionIDFromContext(ctx)
+	if sessionID == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "roots belong to a session, call Initialize first")
+	}
+	if err := validateRoots(req.GetRoots()); err != nil {
+		return nil, err
+	}
+	s.roots.set(sessionID, req.GetRoots())
+
+	additionalHeaders := initHttpHeadersFromContext(ctx)
+	httpReq, err := jsonrpc.NewJSONRPCRequest(ctx, s.mcpUrl, mcpconst.NotificationsRootsListChanged, nil, additionalHeaders, http.NewRequestWithContext)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create http request for %s: %v", mcpconst.NotificationsRootsListChanged, err)
+	}
+	if _, _, err := s.doRequest(ctx, httpReq, nil); err != nil {
+		return nil, err
+	}
+	return &mcp.SetRootsResult{}, nil
+}
+
+// validateRoots checks every root is a file:// uri, as the spec has them
+func validateRoots(roots []*mcp.Root) error {
+	for _, root := range roots {
+		if !strings.HasPrefix(root.GetUri(), "file://") {
+			return status.Errorf(codes.InvalidArgument, "root %q is not a file:// uri", root.GetUri())
+		}
+	}
+	return nil
+}
+
+// withoutRoots is an initialize as the server should see it. The roots stay with the
+// proxy, which declares the roots capability since it answers roots/list itself.
+func withoutRoots(req *mcp.InitializeRequest) *mcp.InitializeRequest {
+	req = proto.Clone(req).(*mcp.InitializeRequest)
+	req.Roots = nil
+	if req.Capabilities == nil {
+		req.Capabilities = &mcp.ClientCapabilities{}
+	}
+	if req.Capabilities.Roots == nil {
+		req.Capabilities.Roots = &mcp.RootsCapability{ListChanged: proto.Bool(true)}
+	}
+	return req
+}
+
+// listRoots is the roots/list result for the session ctx is for.
+func (s *Server) listRoots(ctx context.Context) any {
+	roots := s.roots.get(sessionIDFromContext(ctx))
+	if roots == nil {
+		roots = []*mcp.Root{}
+	}
+	return struct {
+		Roots []*mcp.Root `json:"roots"`
+	}{Roots: roots}
+}
+
+// sessionIDFromContext is the MCP session a call is for, if it has one yet.
+func sessionIDFromContext(ctx context.Context) string {
+	return initHttpHeadersFromContext(ctx)[http.CanonicalHeaderKey(mcpconst.MCP_SESSION_ID_HEADER)]
+}
@@ -0,0 +1,153 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// the example server never asks for roots, so a canned server asks on its GET stream
+// once it's opened and again each time it hears the roots changed, and on the stream
+// of a tool call
+func TestRoots(t *testing.T) {
+
+	assert := assert.New(t)
+
+	initializeParams := make(chan json.RawMessage, 1)
+	listChanged := make(chan struct{}, 1)
+	answers := make(chan jsonrpc2.Response, 2)
+	mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			for id := 1; ; id++ {
+				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%d,\"method\":\"%s\"}\n\n", id, mcpconst.RootsList)
+				w.(http.Flusher).Flush()
+				select {
+				case <-listChanged:
+				case <-r.Context().Done():
+					return
+				}
+			}
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		// requests, notifications and our answers to the server's requests alike
+		var msg struct {
+			ID     jsonrpc2.ID      `json:"id"`
+			Method string           `json:"method"`
+			Params json.RawMessage  `json:"params"`
+			Result *json.RawMessage `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(body, &msg))
+
+		switch mcpconst.JsonRpcMethod(msg.Method) {
+		case mcpconst.Initialize:
+			initializeParams <- msg.Params
+			w.Header().Set(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, "{\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"protocolVersion\":\"%s\"}}", msg.ID, mcpconst.ProtocolVersion)
+		case mcpconst.ToolsCall:
+			// the server can ask as well while it works on a call
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":\"call-roots\",\"method\":\"%s\"}\n\n", mcpconst.RootsList)
+			w.(http.Flusher).Flush()
+			select {
+			case answer := <-answers:
+				text, _ := json.Marshal(string(*answer.Result))
+				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"content\":[{\"type\":\"text\",\"text\":%s}]}}\n\n", msg.ID, text)
+			case <-r.Context().Done():
+			}
+		case mcpconst.NotificationsRootsListChanged:
+			assert.Equal("session-1", r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+			listChanged <- struct{}{}
+			w.WriteHeader(http.StatusAccepted)
+		case "":
+			assert.Equal("session-1", r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+			answers <- jsonrpc2.Response{ID: msg.ID, Result: msg.Result}
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer mcpServer.Close()
+
+	s, err := NewServer(mcpServer.URL)
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	serverCancel, err := s.StartProxyToListenerAsync(lis)
+	require.NoError(t, err)
+	defer serverCancel()
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	mcpGrpcClient := pb.NewModelContextProtocolClient(conn)
+
+	_, err = mcpGrpcClient.Initialize(t.Context(), &pb.InitializeRequest{Roots: []*pb.Root{{Uri: "/not/a/uri"}}})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = mcpGrpcClient.Initialize(t.Context(), &pb.InitializeRequest{
+		ProtocolVersion: mcpconst.ProtocolVersion,
+		Roots:           []*pb.Root{{Uri: "file:///home/alice/src", Name: proto.String("src")}},
+	})
+	require.NoError(t, err)
+
+	// the roots stay with the proxy, which tells the server it has them
+	assert.JSONEq(fmt.Sprintf(`{"protocolVersion": "%s", "capabilities": {"roots": {"listChanged": true}}}`,
+		mcpconst.ProtocolVersion), string(<-initializeParams))
+
+	md := metadata.Pairs(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(t.Context(), md), 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.Session(ctx)
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	nextAnswer := func() string {
+		select {
+		case resp := <-answers:
+			require.NotNil(t, resp.Result)
+			return string(*resp.Result)
+		case <-time.After(5 * time.Second):
+			t.Fatal("server never got its roots")
+		}
+		return ""
+	}
+	assert.JSONEq(`{"roots": [{"uri": "file:///home/alice/src", "name": "src"}]}`, nextAnswer())
+
+	_, err = mcpGrpcClient.SetRoots(ctx, &pb.SetRootsRequest{Roots: []*pb.Root{{Uri: "file:///tmp"}}})
+	require.NoError(t, err)
+	assert.JSONEq(`{"roots": [{"uri": "file:///tmp"}]}`, nextAnswer())
+
+	result, err := mcpGrpcClient.CallMethod(ctx, &pb.CallToolRequest{Name: "anything"})
+	require.NoError(t, err)
+	require.Len(t, result.GetContent(), 1)
+	assert.JSONEq(`{"roots": [{"uri": "file:///tmp"}]}`, result.GetContent()[0].GetText().GetText())
+}
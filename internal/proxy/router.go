@@ -0,0 +1,477 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Router serves ModelContextProtocol in front of several MCP servers, each one a named
+// Server of its own. A call goes to the backend named by the x-mcp-backend metadata,
+// else for tool calls the one named by a "backend/" prefix on the tool, else the
+// default. Sessions are each backend's own, so a caller holding an mcp-session-id has
+// to keep calling the backend it came from.
+type Router struct {
+	backends       map[string]*Server
+	defaultBackend string
+	aggregate      bool
+	maxListPages   int
+}
+
+// NewRouter returns a Router over backends. defaultBackend can be empty, in which case
+// every call has to say which backend it's for.
+func NewRouter(backends map[string]*Server, defaultBackend string) (*Router, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no backends to route to")
+	}
+	for name := range backends {
+		if name == "" || strings.Contains(name, mcpconst.BackendToolSeparator) {
+			return nil, fmt.Errorf("invalid backend name: %q", name)
+		}
+	}
+	if _, ok := backends[defaultBackend]; defaultBackend != "" && !ok {
+		return nil, fmt.Errorf("default backend %s is not one of the backends", defaultBackend)
+	}
+	return &Router{backends: backends, defaultBackend: defaultBackend}, nil
+}
+
+// Close closes every backend.
+func (r *Router) Close() error {
+	var firstErr error
+	for _, backend := range r.backends {
+		if err := backend.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// StartAsync starts the gRPC server in its own goroutine. returns a func to shut it down.
+func (r *Router) StartAsync(port int) (*net.TCPAddr, context.CancelFunc, error) {
+	return listenAsync(port, r.newGrpcServer())
+}
+
+// StartProxyToListenerAsync starts the gRPC server in its own goroutine. returns a func to shut it down.
+func (r *Router) StartProxyToListenerAsync(lis net.Listener) (func(), error) {
+	return serveAsync(lis, r.newGrpcServer()), nil
+}
+
+// newGrpcServer leaves session handling to each backend, applied once the call is
+// routed to it
+func (r *Router) newGrpcServer() *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(r.unaryInterceptor),
+		grpc.StreamInterceptor(r.streamInterceptor),
+	)
+	mcp.RegisterModelContextProtocolServer(grpcServer, r)
+	reflection.Register(grpcServer)
+	return grpcServer
+}
+
+// route picks the backend for a call and returns it along with toolName as that
+// backend knows it, which only differs when toolName carried the backend's prefix.
+func (r *Router) route(ctx context.Context, toolName string) (*Server, string, error) {
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if names := md.Get(mcpconst.BackendHeader); len(names) > 0 {
+			backend, ok := r.backends[names[0]]
+			if !ok {
+				return nil, "", status.Errorf(codes.NotFound, "no such backend: %s", names[0])
+			}
+			return backend, toolName, nil
+		}
+	}
+
+	if name, tool, ok := strings.Cut(toolName, mcpconst.BackendToolSeparator); ok {
+		backend, ok := r.backends[name]
+		if !ok {
+			return nil, "", status.Errorf(codes.NotFound, "no such backend: %s", name)
+		}
+		return backend, tool, nil
+	}
+
+	if r.defaultBackend == "" {
+		return nil, "", status.Errorf(codes.InvalidArgument, "no backend given, set the %s header", mcpconst.BackendHeader)
+	}
+	return r.backends[r.defaultBackend], toolName, nil
+}
+
+// backendContext strips the routing header, which is no business of the MCP server,
+// and then does what the backend's interceptor would.
+func backendContext(ctx context.Context, backend *Server, method string) (context.Context, func(error), error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		md = md.Copy()
+		md.Delete(mcpconst.BackendHeader)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return backend.getInterceptorContext(ctx, method)
+}
+
+// routeUnary calls method on backend with the backend's session handling.
+func routeUnary[Req, Resp any](ctx context.Context, backend *Server, req Req,
+	method func(*Server, context.Context, Req) (Resp, error)) (Resp, error) {
+
+	var noResp Resp
+	fullMethod, _ := grpc.Method(ctx)
+	ctx, done, err := backendContext(ctx, backend, fullMethod)
+	if err != nil {
+		return noResp, err
+	}
+
+	resp, err := method(backend, ctx, req)
+	done(err)
+	return resp, err
+}
+
+// routeStream is routeUnary for streaming calls, handing method the stream with its
+// context swapped for the backend's.
+func routeStream(backend *Server, stream grpc.ServerStream, method func(grpc.ServerStream) error) error {
+
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	ctx, done, err := backendContext(stream.Context(), backend, fullMethod)
+	if err != nil {
+		return err
+	}
+
+	err = method(&sessionServerStream{stream, ctx})
+	done(err)
+	return err
+}
+
+// routeTool routes a tool call, giving back a copy of req with any backend prefix taken
+// off the tool's name.
+func (r *Router) routeTool(ctx context.Context, req *mcp.CallToolRequest) (*Server, *mcp.CallToolRequest, error) {
+	backend, toolName, err := r.route(ctx, req.GetName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if toolName != req.GetName() {
+		req = proto.Clone(req).(*mcp.CallToolRequest)
+		req.Name = toolName
+	}
+	return backend, req, nil
+}
+
+// Initialize implements the Initialize RPC on the routed backend.
+func (r *Router) Initialize(ctx context.Context, req *mcp.InitializeRequest) (*mcp.InitializeResult, error) {
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).Initialize)
+}
+
+// CallMethod implements the CallMethod RPC on the routed backend.
+func (r *Router) CallMethod(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	backend, req, err := r.routeTool(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).CallMethod)
+}
+
+// CallMethodStream implements the CallMethodStream RPC. The whole stream goes to the
+// one backend, so it's routed by header or default only, unless aggregating.
+func (r *Router) CallMethodStream(stream mcp.ModelContextProtocol_CallMethodStreamServer) error {
+	if r.aggregating(stream.Context()) {
+		return r.aggregateCallMethodStream(stream)
+	}
+	backend, _, err := r.route(stream.Context(), "")
+	if err != nil {
+		return err
+	}
+	return routeStream(backend, stream, func(ss grpc.ServerStream) error {
+		return backend.CallMethodStream(&grpc.GenericServerStream[mcp.CallToolRequest, mcp.CallToolResult]{ServerStream: ss})
+	})
+}
+
+// CallMethodBatch implements the CallMethodBatch RPC. Each call is routed like
+// CallMethod's, and each backend gets its share of them as a batch of its own. A call
+// that can't be routed, or whose backend's batch fails, gets that error as its response.
+func (r *Router) CallMethodBatch(ctx context.Context, req *mcp.CallToolBatchRequest) (*mcp.CallToolBatchResult, error) {
+
+	type share struct {
+		batch   *mcp.CallToolBatchRequest
+		indexes []int
+	}
+	result := &mcp.CallToolBatchResult{Responses: make([]*mcp.CallToolBatchResponse, len(req.GetRequests()))}
+	shares := map[*Server]*share{}
+	for i, call := range req.GetRequests() {
+		backend, call, err := r.routeTool(ctx, call)
+		if err != nil {
+			result.Responses[i] = batchResponse(nil, err)
+			continue
+		}
+		if shares[backend] == nil {
+			shares[backend] = &share{batch: &mcp.CallToolBatchRequest{}}
+		}
+		shares[backend].batch.Requests = append(shares[backend].batch.Requests, call)
+		shares[backend].indexes = append(shares[backend].indexes, i)
+	}
+
+	var wg sync.WaitGroup
+	for backend, share := range shares {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			batchResult, err := routeUnary(ctx, backend, share.batch, (*Server).CallMethodBatch)
+			for j, i := range share.indexes {
+				if err != nil {
+					result.Responses[i] = batchResponse(nil, err)
+				} else {
+					result.Responses[i] = batchResult.GetResponses()[j]
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return result, nil
+}
+
+// CallToolWithProgress implements the CallToolWithProgress RPC on the routed backend.
+func (r *Router) CallToolWithProgress(req *mcp.CallToolRequest, stream mcp.ModelContextProtocol_CallToolWithProgressServer) error {
+	backend, req, err := r.routeTool(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return routeStream(backend, stream, func(ss grpc.ServerStream) error {
+		return backend.CallToolWithProgress(req, &grpc.GenericServerStream[mcp.CallToolRequest, mcp.CallToolProgress]{ServerStream: ss})
+	})
+}
+
+// ListTools implements the ListTools RPC on the routed backend.
+func (r *Router) ListTools(ctx context.Context, req *mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	if r.aggregating(ctx) {
+		return r.aggregateTools(ctx, req)
+	}
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).ListTools)
+}
+
+// ListPrompts implements the ListPrompts RPC on the routed backend.
+func (r *Router) ListPrompts(ctx context.Context, req *mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	if r.aggregating(ctx) {
+		return r.aggregatePrompts(ctx, req)
+	}
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).ListPrompts)
+}
+
+// GetPrompt implements the GetPrompt RPC on the routed backend.
+func (r *Router) GetPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	backend, name, err := r.routeNamespaced(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if name != req.GetName() {
+		req = proto.Clone(req).(*mcp.GetPromptRequest)
+		req.Name = name
+	}
+	return routeUnary(ctx, backend, req, (*Server).GetPrompt)
+}
+
+// ListResources implements the ListResources RPC on the routed backend.
+func (r *Router) ListResources(ctx context.Context, req *mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	if r.aggregating(ctx) {
+		return r.aggregateResources(ctx, req)
+	}
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).ListResources)
+}
+
+// ListResourceTemplates implements the ListResourceTemplates RPC on the routed backend.
+func (r *Router) ListResourceTemplates(ctx context.Context, req *mcp.ListResourceTemplatesRequest) (*mcp.ListResourceTemplatesResult, error) {
+	if r.aggregating(ctx) {
+		return r.aggregateResourceTemplates(ctx, req)
+	}
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).ListResourceTemplates)
+}
+
+// ReadResource implements the ReadResource RPC on the routed backend.
+func (r *Router) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	backend, uri, err := r.routeNamespaced(ctx, req.GetUri())
+	if err != nil {
+		return nil, err
+	}
+	if uri == req.GetUri() {
+		return routeUnary(ctx, backend, req, (*Server).ReadResource)
+	}
+
+	asked := req.GetUri()
+	req = proto.Clone(req).(*mcp.ReadResourceRequest)
+	req.Uri = uri
+	result, err := routeUnary(ctx, backend, req, (*Server).ReadResource)
+	if err != nil {
+		return nil, err
+	}
+	// hand the contents back under the uri they were asked for
+	for _, contents := range result.GetContents() {
+		if text := contents.GetText(); text != nil && text.GetUri() == uri {
+			text.Uri = asked
+		}
+		if blob := contents.GetBlob(); blob != nil && blob.GetUri() == uri {
+			blob.Uri = asked
+		}
+	}
+	return result, nil
+}
+
+// SubscribeResource implements the SubscribeResource RPC on the routed backend.
+func (r *Router) SubscribeResource(req *mcp.SubscribeRequest, stream mcp.ModelContextProtocol_SubscribeResourceServer) error {
+	backend, uri, err := r.routeNamespaced(stream.Context(), req.GetUri())
+	if err != nil {
+		return err
+	}
+	asked := req.GetUri()
+	if uri != asked {
+		req = proto.Clone(req).(*mcp.SubscribeRequest)
+		req.Uri = uri
+	}
+	return routeStream(backend, stream, func(ss grpc.ServerStream) error {
+		updates := &grpc.GenericServerStream[mcp.SubscribeRequest, mcp.ResourceUpdatedNotification]{ServerStream: ss}
+		return backend.SubscribeResource(req, &renamedUpdates{updates, asked})
+	})
+}
+
+// renamedUpdates sends resource updates under the uri the caller subscribed to, which
+// for an aggregated subscription has the backend's prefix
+type renamedUpdates struct {
+	mcp.ModelContextProtocol_SubscribeResourceServer
+	uri string
+}
+
+func (ru *renamedUpdates) Send(updated *mcp.ResourceUpdatedNotification) error {
+	updated.Uri = ru.uri
+	return ru.ModelContextProtocol_SubscribeResourceServer.Send(updated)
+}
+
+// Complete implements the Complete RPC on the backend with the prompt or resource
+// template the ref names.
+func (r *Router) Complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	switch ref := req.GetRef().GetRefOneof().(type) {
+	case *mcp.Reference_Prompt:
+		backend, name, err := r.routeNamespaced(ctx, ref.Prompt.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if name != ref.Prompt.GetName() {
+			req = proto.Clone(req).(*mcp.CompleteRequest)
+			req.GetRef().GetPrompt().Name = name
+		}
+		return routeUnary(ctx, backend, req, (*Server).Complete)
+	case *mcp.Reference_ResourceTemplate:
+		backend, uri, err := r.routeNamespaced(ctx, ref.ResourceTemplate.GetUri())
+		if err != nil {
+			return nil, err
+		}
+		if uri != ref.ResourceTemplate.GetUri() {
+			req = proto.Clone(req).(*mcp.CompleteRequest)
+			req.GetRef().GetResourceTemplate().Uri = uri
+		}
+		return routeUnary(ctx, backend, req, (*Server).Complete)
+	}
+
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).Complete)
+}
+
+// SetLoggingLevel implements the SetLoggingLevel RPC on the routed backend.
+func (r *Router) SetLoggingLevel(ctx context.Context, req *mcp.SetLevelRequest) (*mcp.SetLevelResult, error) {
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).SetLoggingLevel)
+}
+
+// StreamLogs implements the StreamLogs RPC on the routed backend.
+func (r *Router) StreamLogs(req *mcp.StreamLogsRequest, stream mcp.ModelContextProtocol_StreamLogsServer) error {
+	backend, _, err := r.route(stream.Context(), "")
+	if err != nil {
+		return err
+	}
+	return routeStream(backend, stream, func(ss grpc.ServerStream) error {
+		return backend.StreamLogs(req, &grpc.GenericServerStream[mcp.StreamLogsRequest, mcp.LoggingMessageNotification]{ServerStream: ss})
+	})
+}
+
+// WatchCatalog implements the WatchCatalog RPC on the routed backend.
+func (r *Router) WatchCatalog(req *mcp.WatchCatalogRequest, stream mcp.ModelContextProtocol_WatchCatalogServer) error {
+	backend, _, err := r.route(stream.Context(), "")
+	if err != nil {
+		return err
+	}
+	return routeStream(backend, stream, func(ss grpc.ServerStream) error {
+		return backend.WatchCatalog(req, &grpc.GenericServerStream[mcp.WatchCatalogRequest, mcp.CatalogChange]{ServerStream: ss})
+	})
+}
+
+// Session implements the Session RPC on the routed backend.
+func (r *Router) Session(stream mcp.ModelContextProtocol_SessionServer) error {
+	backend, _, err := r.route(stream.Context(), "")
+	if err != nil {
+		return err
+	}
+	return routeStream(backend, stream, func(ss grpc.ServerStream) error {
+		return backend.Session(&grpc.GenericServerStream[mcp.ClientResponse, mcp.ServerRequest]{ServerStream: ss})
+	})
+}
+
+// SetRoots implements the SetRoots RPC on the routed backend.
+func (r *Router) SetRoots(ctx context.Context, req *mcp.SetRootsRequest) (*mcp.SetRootsResult, error) {
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).SetRoots)
+}
+
+// Ping implements the Ping RPC on the routed backend.
+func (r *Router) Ping(ctx context.Context, req *mcp.PingRequest) (*mcp.PingResult, error) {
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).Ping)
+}
+
+// Terminate implements the Terminate RPC on the routed backend.
+func (r *Router) Terminate(ctx context.Context, req *mcp.TerminateRequest) (*mcp.TerminateResult, error) {
+	backend, _, err := r.route(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return routeUnary(ctx, backend, req, (*Server).Terminate)
+}
@@ -0,0 +1,121 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newRouterBufconClient(t *testing.T, r *Router) pb.ModelContextProtocolClient {
+
+	lis := bufconn.Listen(1024 * 1024)
+	serverCancel, err := r.StartProxyToListenerAsync(lis)
+	require.NoError(t, err)
+	t.Cleanup(serverCancel)
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewModelContextProtocolClient(conn)
+}
+
+// newExampleBackends starts an example MCP server for each name, with proxy servers for them
+func newExampleBackends(t *testing.T, names ...string) map[string]*Server {
+	backends := map[string]*Server{}
+	for _, name := range names {
+		ts := httptest.NewServer(examplemcp.RunExampleMcpServer(name, "/mcp"))
+		t.Cleanup(ts.Close)
+		s, err := NewServer(ts.URL)
+		require.NoError(t, err)
+		backends[name] = s
+	}
+	return backends
+}
+
+func TestRouterDefault(t *testing.T) {
+
+	r, err := NewRouter(newExampleBackends(t, "github", "jira"), "github")
+	require.NoError(t, err)
+	defer r.Close()
+
+	// everything works against the default backend as it would without a router
+	doMcpClientTests(t, newRouterBufconClient(t, r))
+}
+
+func TestRouter(t *testing.T) {
+
+	assert := assert.New(t)
+
+	backends := newExampleBackends(t, "github", "jira")
+	for _, backend := range backends {
+		backend.ManageSessions(0)
+	}
+	r, err := NewRouter(backends, "github")
+	require.NoError(t, err)
+	defer r.Close()
+	mcpGrpcClient := newRouterBufconClient(t, r)
+
+	serverName := func(ctx context.Context) string {
+		initializeResult, err := mcpGrpcClient.Initialize(ctx, &pb.InitializeRequest{})
+		require.NoError(t, err)
+		return initializeResult.GetServerInfo().GetName()
+	}
+	jiraCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.BackendHeader, "jira")
+	assert.Equal("github", serverName(t.Context()))
+	assert.Equal("jira", serverName(jiraCtx))
+
+	// a session from one backend is good for calls routed to it
+	sessionCtx, err := doProxyInitialize(jiraCtx, mcpGrpcClient)
+	require.NoError(t, err)
+	_, err = mcpGrpcClient.Ping(sessionCtx, &pb.PingRequest{})
+	require.NoError(t, err)
+
+	// tools can be routed by prefix
+	callToolRequest, err := toolTestData[0].NewToolRequest()
+	require.NoError(t, err)
+	callToolRequest.Name = "jira" + mcpconst.BackendToolSeparator + callToolRequest.GetName()
+	callToolResult, err := mcpGrpcClient.CallMethod(t.Context(), callToolRequest)
+	require.NoError(t, err)
+	validateCallToolResult(t, callToolResult, toolTestData[0])
+
+	// asking for a backend there isn't
+	confluenceCtx := metadata.AppendToOutgoingContext(t.Context(), mcpconst.BackendHeader, "confluence")
+	_, err = mcpGrpcClient.Ping(confluenceCtx, &pb.PingRequest{})
+	assert.Equal(codes.NotFound, status.Code(err))
+	callToolRequest.Name = "confluence" + mcpconst.BackendToolSeparator + "add"
+	_, err = mcpGrpcClient.CallMethod(t.Context(), callToolRequest)
+	assert.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestRouterNoDefault(t *testing.T) {
+
+	r, err := NewRouter(newExampleBackends(t, "github"), "")
+	require.NoError(t, err)
+	defer r.Close()
+
+	_, err = newRouterBufconClient(t, r).Ping(t.Context(), &pb.PingRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = NewRouter(newExampleBackends(t, "github"), "jira")
+	assert.Error(t, err)
+	_, err = NewRouter(nil, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// decodeCreateMessageRequest decodes the params of a sampling/createMessage. Message
+// content is polymorphic and roles come as lower case names, so both are decoded here.
+func decodeCreateMessageRequest(params *json.RawMessage) (*mcp.CreateMessageRequest, error) {
+	if params == nil {
+		return nil, fmt.Errorf("sampling request has no params")
+	}
+
+	var rawRequest struct {
+		Messages []struct {
+			Role    string          `json:"role"`
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+		ModelPreferences *mcp.ModelPreferences `json:"modelPreferences"`
+		SystemPrompt     *string               `json:"systemPrompt"`
+		IncludeContext   *string               `json:"includeContext"`
+		Temperature      *float64              `json:"temperature"`
+		MaxTokens        int64                 `json:"maxTokens"`
+		StopSequences    []string              `json:"stopSequences"`
+		Metadata         *structpb.Struct      `json:"metadata"`
+		Meta             *structpb.Struct      `json:"_meta"`
+	}
+	if err := json.Unmarshal(*params, &rawRequest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sampling request: %w", err)
+	}
+
+	request := &mcp.CreateMessageRequest{
+		ModelPreferences: rawRequest.ModelPreferences,
+		SystemPrompt:     rawRequest.SystemPrompt,
+		IncludeContext:   rawRequest.IncludeContext,
+		Temperature:      rawRequest.Temperature,
+		MaxTokens:        rawRequest.MaxTokens,
+		StopSequences:    rawRequest.StopSequences,
+		Metadata:         rawRequest.Metadata,
+		XMeta:            rawRequest.Meta,
+	}
+	for _, rawMessage := range rawRequest.Messages {
+		contentBlock, err := decodeContentBlock(rawMessage.Content)
+		if err != nil {
+			return nil, err
+		}
+		if contentBlock == nil {
+			continue
+		}
+		request.Messages = append(request.Messages, &mcp.SamplingMessage{
+			Role:    mcp.Role(mcp.Role_value[strings.ToUpper(rawMessage.Role)]),
+			Content: contentBlock,
+		})
+	}
+
+	return request, nil
+}
+
+// encodeCreateMessageResult puts the client's sampling result in the shape the MCP
+// server expects it.
+func encodeCreateMessageResult(result *mcp.CreateMessageResult) (any, error) {
+	content, err := encodeSamplingContent(result.GetContent())
+	if err != nil {
+		return nil, err
+	}
+
+	// a sampled message is the assistant's unless the client says otherwise
+	role := result.GetRole()
+	if role == mcp.Role_ROLE_UNSPECIFIED {
+		role = mcp.Role_ASSISTANT
+	}
+
+	return struct {
+		Role       string           `json:"role"`
+		Content    map[string]any   `json:"content"`
+		Model      string           `json:"model"`
+		StopReason *string          `json:"stopReason,omitempty"`
+		Meta       *structpb.Struct `json:"_meta,omitempty"`
+	}{
+		Role:       strings.ToLower(role.String()),
+		Content:    content,
+		Model:      result.GetModel(),
+		StopReason: result.StopReason,
+		Meta:       result.GetXMeta(),
+	}, nil
+}
+
+// encodeSamplingContent is decodeContentBlock the other way around, for the content
+// types sampling allows.
+func encodeSamplingContent(contentBlock *mcp.ContentBlock) (map[string]any, error) {
+	var contentType string
+	var content any
+	switch c := contentBlock.GetContentType().(type) {
+	case *mcp.ContentBlock_Text:
+		contentType, content = "text", c.Text
+	case *mcp.ContentBlock_Image:
+		contentType, content = "image", c.Image
+	case *mcp.ContentBlock_Audio:
+		contentType, content = "audio", c.Audio
+	default:
+		return nil, fmt.Errorf("sampling content has to be text, image or audio")
+	}
+
+	// data encodes as base64, as the wire wants it
+	rawContent, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s content: %w", contentType, err)
+	}
+	var encoded map[string]any
+	if err := json.Unmarshal(rawContent, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to marshal %s content: %w", contentType, err)
+	}
+	encoded["type"] = contentType
+	if contentType == "text" {
+		// text is required even when it's empty, which omitempty would drop
+		encoded["text"] = contentBlock.GetText().GetText()
+	}
+	return encoded, nil
+}
@@ -3,30 +3,84 @@ package proxy
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
+	"time"
 
+	"grpc2mcp/internal/stdio"
 	mcp "grpc2mcp/pb"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 // Server is the gRPC server that implements the ModelContextProtocolServer interface.
 type Server struct {
-	mcpUrl     string
-	httpClient http.Client
+	mcpUrl        string
+	httpClient    http.Client
+	typedTools    *typedTools
+	sessions      *managedSessions
+	pool          *sessionPool
+	maxListPages  int
+	elicitTimeout time.Duration
+	roots         sessionRoots
+	maxResumes    int
+
+	sessionStreams sessionStreams
+	batching       batchingSessions
 }
 
 func NewServer(mcpUrl string) (*Server, error) {
 	return &Server{
-		mcpUrl: mcpUrl,
+		mcpUrl:     mcpUrl,
+		maxResumes: DefaultMaxStreamResumes,
+	}, nil
+}
+
+// NewStdioServer returns a Server whose backend is an MCP server spoken to over the
+// stdio of a child process running command, rather than over http.
+func NewStdioServer(command string, args ...string) (*Server, error) {
+	transport := stdio.NewTransport(command, args...)
+	return &Server{
+		mcpUrl:     transport.URL(),
+		httpClient: http.Client{Transport: transport},
+		maxResumes: DefaultMaxStreamResumes,
 	}, nil
 }
 
+// Close releases what the server holds onto for its backend, ending any managed and
+// pooled sessions and, for a stdio backend, stopping the child process.
+func (s *Server) Close() error {
+	if s.sessions != nil {
+		s.sessions.close()
+	}
+	if s.pool != nil {
+		s.pool.close()
+	}
+	if closer, ok := s.httpClient.Transport.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // Start starts the gRPC server in its own goroutine. returns a func to shut it down.
 func (s *Server) StartAsync(port int) (*net.TCPAddr, context.CancelFunc, error) {
+	return listenAsync(port, s.newGrpcServer())
+}
+
+// StartProxyToListenerAsync starts the gRPC server in its own goroutine. returns a func to shut it down.
+func (s *Server) StartProxyToListenerAsync(lis net.Listener) (func(), error) {
+	return serveAsync(lis, s.newGrpcServer()), nil
+}
+
+// listenAsync serves grpcServer on port in its own goroutine. returns a func to shut it down.
+func listenAsync(port int, grpcServer *grpc.Server) (*net.TCPAddr, context.CancelFunc, error) {
 
 	noopCancelFunc := func() {}
 
@@ -35,12 +89,13 @@ func (s *Server) StartAsync(port int) (*net.TCPAddr, context.CancelFunc, error)
 		return nil, noopCancelFunc, err
 	}
 
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(unarySessionInterceptor),
-		grpc.StreamInterceptor(streamSessionInterceptor),
-	)
-	mcp.RegisterModelContextProtocolServer(grpcServer, s)
-	reflection.Register(grpcServer)
+	stop := serveAsync(lis, grpcServer)
+	tcpAddr, _ := lis.Addr().(*net.TCPAddr)
+	return tcpAddr, stop, nil
+}
+
+// serveAsync serves grpcServer on lis in its own goroutine. returns a func to shut it down.
+func serveAsync(lis net.Listener, grpcServer *grpc.Server) func() {
 	go func() {
 		err := grpcServer.Serve(lis)
 		if err != nil {
@@ -48,25 +103,35 @@ func (s *Server) StartAsync(port int) (*net.TCPAddr, context.CancelFunc, error)
 		}
 	}()
 
-	tcpAddr, _ := lis.Addr().(*net.TCPAddr)
-	return tcpAddr, grpcServer.GracefulStop, nil
+	return grpcServer.GracefulStop
 }
 
-// StartProxyToListenerAsync starts the gRPC server in its own goroutine. returns a func to shut it down.
-func (s *Server) StartProxyToListenerAsync(lis net.Listener) (func(), error) {
+// newGrpcServer registers ModelContextProtocol, any typed tools service and reflection
+// on a new grpc server.
+func (s *Server) newGrpcServer() *grpc.Server {
 
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(unarySessionInterceptor),
-		grpc.StreamInterceptor(streamSessionInterceptor),
+		grpc.UnaryInterceptor(s.unarySessionInterceptor),
+		grpc.StreamInterceptor(s.streamSessionInterceptor),
 	)
 	mcp.RegisterModelContextProtocolServer(grpcServer, s)
-	reflection.Register(grpcServer)
-	go func() {
-		err := grpcServer.Serve(lis)
-		if err != nil {
-			log.Printf("grpcServer.Serve error: %v", err)
-		}
-	}()
 
-	return grpcServer.GracefulStop, nil
+	if s.typedTools == nil {
+		reflection.Register(grpcServer)
+		return grpcServer
+	}
+
+	grpcServer.RegisterService(s.typedTools.serviceDesc(s), s)
+
+	// the generated file isn't in the global registry, so reflection needs to be told of it
+	files := &protoregistry.Files{}
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		files.RegisterFile(fd)
+		return true
+	})
+	files.RegisterFile(s.typedTools.descriptor)
+	opts := reflection.ServerOptions{Services: grpcServer, DescriptorResolver: files}
+	reflectionv1.RegisterServerReflectionServer(grpcServer, reflection.NewServerV1(opts))
+	reflectionv1alpha.RegisterServerReflectionServer(grpcServer, reflection.NewServer(opts))
+	return grpcServer
 }
@@ -0,0 +1,322 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// how long we give the MCP server to take the answers a client left behind
+const refuseServerRequestsTimeout = 5 * time.Second
+
+// serverRequest is a request the MCP server is waiting on the client to answer.
+type serverRequest struct {
+	id     jsonrpc2.ID
+	method mcpconst.JsonRpcMethod
+	// gives up on the request, if it has a timeout
+	timer *time.Timer
+}
+
+// serverRequests are the requests the MCP server has made of a Session stream's client
+// and that are still waiting on an answer, by the requestId the client was given.
+type serverRequests struct {
+	mu       sync.Mutex
+	requests map[string]*serverRequest
+}
+
+func newServerRequests() *serverRequests {
+	return &serverRequests{requests: map[string]*serverRequest{}}
+}
+
+// add returns the requestId the client answers id with. A request with a timeout is
+// taken and handed to onTimeout if the client hasn't answered it by then.
+func (sr *serverRequests) add(id jsonrpc2.ID, method mcpconst.JsonRpcMethod, timeout time.Duration,
+	onTimeout func(*serverRequest)) string {
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	requestID := id.String()
+	req := &serverRequest{id: id, method: method}
+	if timeout > 0 {
+		req.timer = time.AfterFunc(timeout, func() {
+			if req, ok := sr.take(requestID); ok {
+				onTimeout(req)
+			}
+		})
+	}
+	sr.requests[requestID] = req
+	return requestID
+}
+
+// take returns the request requestId names, which is answered once taken.
+func (sr *serverRequests) take(requestID string) (*serverRequest, bool) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	req, ok := sr.requests[requestID]
+	if !ok {
+		return nil, false
+	}
+	delete(sr.requests, requestID)
+	if req.timer != nil {
+		req.timer.Stop()
+	}
+	return req, true
+}
+
+// takeAll takes every request still waiting.
+func (sr *serverRequests) takeAll() []*serverRequest {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	var reqs []*serverRequest
+	for requestID, req := range sr.requests {
+		if req.timer != nil {
+			req.timer.Stop()
+		}
+		reqs = append(reqs, req)
+		delete(sr.requests, requestID)
+	}
+	return reqs
+}
+
+// sessionStream is a client's open Session stream, which the MCP server's requests of
+// the client are relayed on whichever stream of the session they arrive on.
+type sessionStream struct {
+	ctx     context.Context
+	pending *serverRequests
+
+	// a gRPC stream takes one Send at a time
+	mu     sync.Mutex
+	stream mcp.ModelContextProtocol_SessionServer
+}
+
+func (ss *sessionStream) send(req *mcp.ServerRequest) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.stream.Send(req)
+}
+
+// sessionStreams are the open Session streams by MCP session, the latest for each.
+type sessionStreams struct {
+	mu        sync.Mutex
+	bySession map[string]*sessionStream
+}
+
+func (ss *sessionStreams) open(sessionID string, stream *sessionStream) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.bySession == nil {
+		ss.bySession = map[string]*sessionStream{}
+	}
+	ss.bySession[sessionID] = stream
+}
+
+func (ss *sessionStreams) get(sessionID string) *sessionStream {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.bySession[sessionID]
+}
+
+// close drops stream, unless a newer one has taken its place.
+func (ss *sessionStreams) close(sessionID string, stream *sessionStream) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.bySession[sessionID] == stream {
+		delete(ss.bySession, sessionID)
+	}
+}
+
+// Session implements the Session RPC. It holds the session's GET/SSE stream open and
+// sends the client the requests the MCP server makes of it, for sampling or eliciting
+// input from the user, on this stream or on the response to any of the session's
+// calls. The client's answers are POSTed back to the server as JSON-RPC responses.
+// The stream ends when either side closes it.
+func (s *Server) Session(stream mcp.ModelContextProtocol_SessionServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	httpResp, err := s.openListenStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	// sending the headers lets the client know the stream is listening
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	relay := &sessionStream{ctx: ctx, pending: newServerRequests(), stream: stream}
+	defer s.refuseServerRequests(ctx, relay.pending)
+
+	sessionID := sessionIDFromContext(ctx)
+	s.sessionStreams.open(sessionID, relay)
+	defer s.sessionStreams.close(sessionID, relay)
+
+	clientDone := make(chan error, 1)
+	go func() {
+		clientDone <- s.relayClientResponses(ctx, stream, relay.pending)
+		// no more answers are coming, so stop taking requests
+		cancel()
+	}()
+
+	err = jsonrpc.ReadMessages(httpResp.Body, func(msg *jsonrpc2.Request) error {
+		if msg.Notif {
+			return nil
+		}
+		return s.relayServerRequest(relay, msg)
+	})
+
+	// the client hanging up is how a session stream normally ends
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return <-clientDone
+	}
+	return err
+}
+
+// relayServerRequest sends a request from the MCP server on to the client, or answers
+// it straight away if it's one the proxy answers itself or can't relay.
+func (s *Server) relayServerRequest(relay *sessionStream, msg *jsonrpc2.Request) error {
+	ctx := relay.ctx
+
+	if s.answerServerRequest(ctx, msg) {
+		return nil
+	}
+
+	method := mcpconst.JsonRpcMethod(msg.Method)
+	var rpcErr *jsonrpc2.Error
+	switch method {
+	case mcpconst.SamplingCreateMessage:
+		createMessage, err := decodeCreateMessageRequest(msg.Params)
+		if err == nil {
+			return relay.send(&mcp.ServerRequest{
+				RequestId: relay.pending.add(msg.ID, method, 0, nil),
+				Request:   &mcp.ServerRequest_CreateMessage{CreateMessage: createMessage},
+			})
+		}
+		rpcErr = &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+	case mcpconst.ElicitationCreate:
+		elicit, err := decodeElicitRequest(msg.Params)
+		if err == nil {
+			// a user may never get around to answering, so these don't wait forever
+			requestID := relay.pending.add(msg.ID, method, s.elicitationTimeout(), func(req *serverRequest) {
+				s.giveUpOn(ctx, req)
+			})
+			return relay.send(&mcp.ServerRequest{
+				RequestId: requestID,
+				Request:   &mcp.ServerRequest_Elicit{Elicit: elicit},
+			})
+		}
+		rpcErr = &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+	default:
+		s.refuseServerRequest(ctx, msg)
+		return nil
+	}
+
+	// not every server takes answers like these well, which is no reason to end the stream
+	if err := s.respondToServer(ctx, msg.ID, nil, rpcErr); err != nil {
+		log.Printf("failed to answer %s request %s: %v", msg.Method, msg.ID, err)
+	}
+	return nil
+}
+
+// relayClientResponses POSTs each of the client's answers back to the MCP server until
+// the client closes its side of the stream.
+func (s *Server) relayClientResponses(ctx context.Context, stream mcp.ModelContextProtocol_SessionServer,
+	pending *serverRequests) error {
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		req, ok := pending.take(resp.GetRequestId())
+		if !ok {
+			log.Printf("dropping answer to %s, which the MCP server isn't waiting on", resp.GetRequestId())
+			continue
+		}
+
+		var result any
+		var rpcErr *jsonrpc2.Error
+		switch response := resp.GetResponse().(type) {
+		case *mcp.ClientResponse_CreateMessage:
+			result, err = encodeCreateMessageResult(response.CreateMessage)
+			if err != nil {
+				rpcErr = &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: err.Error()}
+			}
+		case *mcp.ClientResponse_Elicit:
+			result, err = encodeElicitResult(response.Elicit)
+			if err != nil {
+				rpcErr = &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+			}
+		case *mcp.ClientResponse_Error:
+			rpcErr = &jsonrpc2.Error{Code: int64(response.Error.GetCode()), Message: response.Error.GetMessage()}
+		default:
+			rpcErr = &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "client answered with neither a result nor an error"}
+		}
+
+		if err := s.respondToServer(ctx, req.id, result, rpcErr); err != nil {
+			return err
+		}
+	}
+}
+
+// respondToServer POSTs the answer to request id back to the MCP server.
+func (s *Server) respondToServer(ctx context.Context, id jsonrpc2.ID, result any, rpcErr *jsonrpc2.Error) error {
+	additionalHeaders := initHttpHeadersFromContext(ctx)
+	httpReq, err := jsonrpc.NewJSONRPCResponse(ctx, s.mcpUrl, id, result, rpcErr, additionalHeaders, http.NewRequestWithContext)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create response to request %s: %v", id, err)
+	}
+
+	_, _, err = jsonrpc.DoRequest(ctx, &s.httpClient, httpReq)
+	return err
+}
+
+// refuseServerRequests answers whatever the client left unanswered, so the MCP server
+// isn't left waiting. ctx is usually already cancelled by now so we only borrow its
+// headers.
+func (s *Server) refuseServerRequests(ctx context.Context, pending *serverRequests) {
+	refuseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refuseServerRequestsTimeout)
+	defer cancel()
+
+	for _, req := range pending.takeAll() {
+		s.giveUpOn(refuseCtx, req)
+	}
+}
+
+// giveUpOn answers req for a client that didn't. An elicitation the user never got to
+// is cancelled, as the spec has it for one they dismiss, anything else is an error.
+func (s *Server) giveUpOn(ctx context.Context, req *serverRequest) {
+	var result any
+	var rpcErr *jsonrpc2.Error
+	if req.method == mcpconst.ElicitationCreate {
+		result = elicitResult{Action: strings.ToLower(mcp.ElicitAction_CANCEL.String())}
+	} else {
+		rpcErr = &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "client went away before answering"}
+	}
+
+	if err := s.respondToServer(ctx, req.id, result, rpcErr); err != nil {
+		log.Printf("failed to give up on %s request %s: %v", req.method, req.id, err)
+	}
+}
@@ -0,0 +1,37 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Terminate implements the Terminate RPC, ending the caller's session on the MCP
+// server with a DELETE. The session id comes from the same headers as any other call.
+func (s *Server) Terminate(ctx context.Context, req *mcp.TerminateRequest) (*mcp.TerminateResult, error) {
+	if err := s.terminateSession(ctx); err != nil {
+		return nil, err
+	}
+	s.roots.forget(sessionIDFromContext(ctx))
+	s.batching.forget(sessionIDFromContext(ctx))
+	// a managed session is opened again the next time the caller needs one
+	if key, ok := ctx.Value(managedSessionKey{}).(string); ok && s.sessions != nil {
+		s.sessions.forget(key, initHttpHeadersFromContext(ctx)[http.CanonicalHeaderKey(mcpconst.MCP_SESSION_ID_HEADER)])
+	}
+	return &mcp.TerminateResult{}, nil
+}
+
+func (s *Server) terminateSession(ctx context.Context) error {
+	additionalHeaders := initHttpHeadersFromContext(ctx)
+	httpReq, err := jsonrpc.NewTerminateRequest(ctx, s.mcpUrl, additionalHeaders, http.NewRequestWithContext)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create terminate request: %v", err)
+	}
+	return jsonrpc.DoTerminate(ctx, &s.httpClient, httpReq)
+}
@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// the example server always lets a session end, so the other answers come from a
+// canned server
+func TestTerminateStatusCodes(t *testing.T) {
+
+	tests := []struct {
+		httpStatus int
+		code       codes.Code
+	}{
+		{http.StatusOK, codes.OK},
+		{http.StatusNoContent, codes.OK},
+		{http.StatusNotFound, codes.NotFound},
+		{http.StatusMethodNotAllowed, codes.Unimplemented},
+		{http.StatusInternalServerError, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.httpStatus), func(t *testing.T) {
+			mcpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodDelete, r.Method)
+				assert.Equal(t, "session-1", r.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+				w.WriteHeader(tt.httpStatus)
+			}))
+			defer mcpServer.Close()
+
+			s, err := NewServer(mcpServer.URL)
+			require.NoError(t, err)
+
+			md := metadata.Pairs(mcpconst.MCP_SESSION_ID_HEADER, "session-1")
+			_, err = s.Terminate(metadata.NewIncomingContext(t.Context(), md), &pb.TerminateRequest{})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
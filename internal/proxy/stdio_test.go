@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/pb"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const stdioHelperEnv = "GRPC2MCP_STDIO_HELPER"
+
+// TestStdioHelperProcess isn't a real test, it's this test binary re-executed as a
+// stdio MCP server for TestStdio to proxy to.
+func TestStdioHelperProcess(t *testing.T) {
+	if os.Getenv(stdioHelperEnv) != "1" {
+		t.Skip("only runs as a child process")
+	}
+	if err := examplemcp.RunExampleStdioServer(context.Background(), t.Name(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "stdio helper failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestStdio(t *testing.T) {
+
+	t.Setenv(stdioHelperEnv, "1")
+	s, err := NewStdioServer(os.Args[0], "-test.run=^TestStdioHelperProcess$")
+	require.NoError(t, err)
+	defer s.Close()
+
+	const bufSize = 1024 * 1024
+	lis := bufconn.Listen(bufSize)
+	serverCancel, err := s.StartProxyToListenerAsync(lis)
+	require.NoError(t, err)
+	defer serverCancel()
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	mcpGrpcClient := pb.NewModelContextProtocolClient(conn)
+	require.NotNil(t, mcpGrpcClient)
+
+	// resource subscriptions and completions are http only extensions of the example server
+	doGrpcProxyTests(t, mcpGrpcClient)
+	doGrpcProxyToolTests(t, mcpGrpcClient)
+	doGrpcProxyContentTests(t, mcpGrpcClient)
+	doGrpcProxyPromptTests(t, mcpGrpcClient)
+	doGrpcProxyResourceTests(t, mcpGrpcClient)
+	doGrpcProxyStreamTests(t, mcpGrpcClient)
+	doGrpcProxyListAllTests(t, mcpGrpcClient)
+
+	// the child process has the one session, which it doesn't let a caller end
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoError(t, err)
+	_, err = mcpGrpcClient.Terminate(sessionCtx, &pb.TerminateRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -6,8 +6,8 @@ import (
 	mcp "grpc2mcp/pb"
 )
 
-// CallMethodStream
-
+// CallMethodStream calls each tool the client sends in turn, one POST apiece.
+// CallMethodBatch is for sending many calls at once.
 func (s *Server) CallMethodStream(stream mcp.ModelContextProtocol_CallMethodStreamServer) error {
 	ctx := stream.Context()
 
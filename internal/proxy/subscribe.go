@@ -0,0 +1,83 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+	mcp "grpc2mcp/pb"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// how long we give the MCP server to take an unsubscribe once the gRPC stream is gone
+const unsubscribeTimeout = 5 * time.Second
+
+// SubscribeResource implements the SubscribeResource RPC. It subscribes to the uri
+// and streams every notifications/resources/updated for it until the client goes away,
+// at which point it unsubscribes.
+func (s *Server) SubscribeResource(req *mcp.SubscribeRequest, stream mcp.ModelContextProtocol_SubscribeResourceServer) error {
+	ctx := stream.Context()
+
+	// open the listening channel before subscribing so we can't miss an update
+	httpResp, err := s.openListenStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	var subscribeResult struct{}
+	if err := s.doRpcCall(ctx, req, mcpconst.ResourcesSubscribe, &subscribeResult); err != nil {
+		return err
+	}
+	defer s.unsubscribeResource(ctx, req.GetUri())
+
+	// sending the headers lets the client know the subscription is in place
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	err = jsonrpc.ReadMessages(httpResp.Body, func(msg *jsonrpc2.Request) error {
+		if !msg.Notif {
+			s.handleServerRequest(ctx, msg)
+			return nil
+		}
+		if msg.Method != string(mcpconst.NotificationsResourcesUpdated) || msg.Params == nil {
+			return nil
+		}
+
+		var updated mcp.ResourceUpdatedNotification
+		if err := json.Unmarshal(*msg.Params, &updated); err != nil {
+			return status.Errorf(codes.Internal, "failed to unmarshal resource update: %v", err)
+		}
+		if updated.GetUri() != req.GetUri() {
+			return nil
+		}
+		return stream.Send(&updated)
+	})
+
+	// the client hanging up is how a subscription normally ends
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// unsubscribeResource sends resources/unsubscribe for uri. ctx is usually already
+// cancelled by now so we only borrow its headers.
+func (s *Server) unsubscribeResource(ctx context.Context, uri string) {
+	unsubscribeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unsubscribeTimeout)
+	defer cancel()
+
+	var unsubscribeResult struct{}
+	req := &mcp.SubscribeRequest{Uri: uri}
+	if err := s.doRpcCall(unsubscribeCtx, req, mcpconst.ResourcesUnsubscribe, &unsubscribeResult); err != nil {
+		log.Printf("failed to unsubscribe from %s: %v", uri, err)
+	}
+}
@@ -0,0 +1,51 @@
+package proxy
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestSubscribeResource_Unsubscribes(t *testing.T) {
+
+	handler := examplemcp.RunExampleMcpServer(t.Name(), "/mcp")
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+	s, err := NewServer(ts.URL)
+	require.NoError(t, err)
+	mcpGrpcClient := newBufconClient(t, s)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoError(t, err)
+	md, _ := metadata.FromOutgoingContext(sessionCtx)
+	sessionID := md.Get(mcpconst.MCP_SESSION_ID_HEADER)[0]
+
+	subscribeCtx, cancel := context.WithTimeout(sessionCtx, 5*time.Second)
+	defer cancel()
+	stream, err := mcpGrpcClient.SubscribeResource(subscribeCtx, &pb.SubscribeRequest{Uri: examplemcp.RESOURCE_URI_STATIC})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, handler.Subscribed(sessionID, examplemcp.RESOURCE_URI_STATIC))
+
+	// later updates reach the subscribed session too
+	handler.UpdateResource(examplemcp.RESOURCE_URI_STATIC)
+	updated, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, examplemcp.RESOURCE_URI_STATIC, updated.GetUri())
+
+	// hanging up is the client's unsubscribe, which the proxy passes on
+	cancel()
+	assert.Eventually(t, func() bool {
+		return !handler.Subscribed(sessionID, examplemcp.RESOURCE_URI_STATIC)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -13,12 +13,16 @@ import (
 	"log"
 	"net/http/httptest"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -27,6 +31,20 @@ import (
 
 func doGrpcProxyTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
 
+	// Initialize should hand back what the server told us about itself
+	initializeResult, err := mcpGrpcClient.Initialize(t.Context(), &pb.InitializeRequest{})
+	require.NoErrorf(t, err, "error with Initialize")
+	require.NotNil(t, initializeResult.GetServerInfo())
+	require.NotNil(t, initializeResult.GetCapabilities())
+	assert.NotEmpty(t, initializeResult.GetProtocolVersion())
+	assert.NotEmpty(t, initializeResult.GetServerInfo().GetName())
+	assert.Equal(t, examplemcp.SERVER_VERSION, initializeResult.GetServerInfo().GetVersion())
+	assert.Equal(t, examplemcp.SERVER_INSTRUCTIONS, initializeResult.GetInstructions())
+	assert.True(t, initializeResult.GetCapabilities().GetTools().GetListChanged())
+	assert.True(t, initializeResult.GetCapabilities().GetResources().GetSubscribe())
+	assert.True(t, initializeResult.GetCapabilities().GetPrompts().GetListChanged())
+	assert.NotNil(t, initializeResult.GetCapabilities().GetLogging())
+
 	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
 	require.NoError(t, err)
 
@@ -160,6 +178,41 @@ func getResultFromContentBlock(cb *pb.ContentBlock) (any, bool) {
 
 }
 
+func doGrpcProxyContentTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doProxyInitialize")
+
+	callToolResult, err := mcpGrpcClient.CallMethod(sessionCtx, &pb.CallToolRequest{Name: examplemcp.TOOL_SAMPLE_CONTENT})
+	require.NoErrorf(t, err, "error with CallMethod")
+	assert.False(callToolResult.GetIsError())
+
+	content := callToolResult.GetContent()
+	require.Len(t, content, 4, "expected image, audio and two embedded resources")
+
+	image := content[0].GetImage()
+	require.NotNil(t, image, "expected image content, got %T", content[0].GetContentType())
+	assert.Equal(examplemcp.SampleImageData, image.GetData())
+	assert.Equal("image/png", image.GetMimeType())
+
+	audio := content[1].GetAudio()
+	require.NotNil(t, audio, "expected audio content, got %T", content[1].GetContentType())
+	assert.Equal(examplemcp.SampleAudioData, audio.GetData())
+	assert.Equal("audio/wav", audio.GetMimeType())
+
+	textResource := content[2].GetEmbeddedResource().GetTextResource()
+	require.NotNil(t, textResource, "expected embedded text resource, got %T", content[2].GetContentType())
+	assert.Equal(examplemcp.RESOURCE_URI_STATIC, textResource.GetUri())
+	assert.Equal(examplemcp.RESOURCE_TEXT_STATIC, textResource.GetText())
+
+	blobResource := content[3].GetEmbeddedResource().GetBlobResource()
+	require.NotNil(t, blobResource, "expected embedded blob resource, got %T", content[3].GetContentType())
+	assert.Equal(examplemcp.RESOURCE_URI_STATIC_BLOB, blobResource.GetUri())
+	assert.Equal(examplemcp.ResourceBlobStatic, blobResource.GetBlob())
+}
+
 func doGrpcProxyResourceTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
 
 	assert := assert.New(t)
@@ -182,6 +235,247 @@ func doGrpcProxyResourceTests(t *testing.T, mcpGrpcClient pb.ModelContextProtoco
 
 	assert.Equalf(resourceNamesExpected, resourceNamesProvided, "resource names mis matched")
 
+	// now read them back, text first then the blob
+	readResourceResult, err := mcpGrpcClient.ReadResource(sessionCtx,
+		&pb.ReadResourceRequest{Uri: examplemcp.RESOURCE_URI_STATIC})
+	require.NoErrorf(t, err, "error with ReadResource")
+	require.Len(t, readResourceResult.GetContents(), 1)
+	textContents := readResourceResult.GetContents()[0].GetText()
+	require.NotNil(t, textContents, "expected text resource contents")
+	assert.Equal(examplemcp.RESOURCE_URI_STATIC, textContents.GetUri())
+	assert.Equal(examplemcp.RESOURCE_TEXT_STATIC, textContents.GetText())
+
+	readResourceResult, err = mcpGrpcClient.ReadResource(sessionCtx,
+		&pb.ReadResourceRequest{Uri: examplemcp.RESOURCE_URI_STATIC_BLOB})
+	require.NoErrorf(t, err, "error with ReadResource")
+	require.Len(t, readResourceResult.GetContents(), 1)
+	blobContents := readResourceResult.GetContents()[0].GetBlob()
+	require.NotNil(t, blobContents, "expected blob resource contents")
+	assert.Equal(examplemcp.RESOURCE_URI_STATIC_BLOB, blobContents.GetUri())
+	assert.Equal(examplemcp.ResourceBlobStatic, blobContents.GetBlob())
+
+}
+
+func doGrpcProxySubscribeTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doProxyInitialize")
+
+	// cancelling is how a client unsubscribes, the timeout keeps us from hanging
+	subscribeCtx, cancel := context.WithTimeout(sessionCtx, 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.SubscribeResource(subscribeCtx,
+		&pb.SubscribeRequest{Uri: examplemcp.RESOURCE_URI_STATIC})
+	require.NoErrorf(t, err, "error with SubscribeResource")
+
+	// the example server sends an update as soon as we subscribe
+	updated, err := stream.Recv()
+	require.NoErrorf(t, err, "error on stream.Recv")
+	assert.Equal(examplemcp.RESOURCE_URI_STATIC, updated.GetUri())
+}
+
+func doGrpcProxyLoggingTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doProxyInitialize")
+
+	_, err = mcpGrpcClient.SetLoggingLevel(sessionCtx, &pb.SetLevelRequest{})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = mcpGrpcClient.SetLoggingLevel(sessionCtx, &pb.SetLevelRequest{Level: pb.LoggingLevel_DEBUG})
+	require.NoErrorf(t, err, "error with SetLoggingLevel")
+
+	// cancelling is how a client stops listening, the timeout keeps us from hanging
+	streamCtx, cancel := context.WithTimeout(sessionCtx, 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.StreamLogs(streamCtx, &pb.StreamLogsRequest{Level: pb.LoggingLevel_WARNING.Enum()})
+	require.NoErrorf(t, err, "error with StreamLogs")
+
+	// the example server logs at every level once its level is set, we only see the severe ones
+	for _, level := range []pb.LoggingLevel{pb.LoggingLevel_WARNING, pb.LoggingLevel_ERROR,
+		pb.LoggingLevel_CRITICAL, pb.LoggingLevel_ALERT, pb.LoggingLevel_EMERGENCY} {
+
+		logMessage, err := stream.Recv()
+		require.NoErrorf(t, err, "error on stream.Recv")
+		assert.Equal(level, logMessage.GetLevel())
+		assert.Equal(examplemcp.LOGGER_NAME, logMessage.GetLogger())
+		assert.Equal(fmt.Sprintf("a %s message", strings.ToLower(level.String())), logMessage.GetData().GetStringValue())
+	}
+}
+
+func doGrpcProxySamplingTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doProxyInitialize")
+
+	// cancelling is how a client ends its session stream, the timeout keeps us from hanging
+	streamCtx, cancel := context.WithTimeout(sessionCtx, 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.Session(streamCtx)
+	require.NoErrorf(t, err, "error with Session")
+	// the header means the proxy is listening for the server's requests
+	_, err = stream.Header()
+	require.NoErrorf(t, err, "error waiting on the Session header")
+
+	summarize := func(s string) <-chan *pb.CallToolResult {
+		results := make(chan *pb.CallToolResult, 1)
+		go func() {
+			defer close(results)
+			argsStruct, err := structpb.NewStruct(map[string]any{examplemcp.PARAM_S: s})
+			if !assert.NoErrorf(err, "error making NewStruct") {
+				return
+			}
+			callToolResult, err := mcpGrpcClient.CallMethod(sessionCtx,
+				&pb.CallToolRequest{Name: examplemcp.TOOL_SUMMARIZE, Arguments: argsStruct.GetFields()})
+			if assert.NoErrorf(err, "error with CallMethod") {
+				results <- callToolResult
+			}
+		}()
+		return results
+	}
+
+	const text = "the quick brown fox jumps over the lazy dog"
+	results := summarize(text)
+
+	serverRequest, err := stream.Recv()
+	require.NoErrorf(t, err, "error on stream.Recv")
+	createMessage := serverRequest.GetCreateMessage()
+	require.NotNil(t, createMessage, "expected a createMessage request")
+	assert.Equal(examplemcp.SUMMARIZE_SYSTEM_PROMPT, createMessage.GetSystemPrompt())
+	assert.Equal(int64(examplemcp.SUMMARIZE_MAX_TOKENS), createMessage.GetMaxTokens())
+	require.Len(t, createMessage.GetMessages(), 1)
+	assert.Equal(pb.Role_USER, createMessage.GetMessages()[0].GetRole())
+	assert.Equal(text, createMessage.GetMessages()[0].GetContent().GetText().GetText())
+
+	const summary = "a fox jumps a dog"
+	err = stream.Send(&pb.ClientResponse{
+		RequestId: serverRequest.GetRequestId(),
+		Response: &pb.ClientResponse_CreateMessage{CreateMessage: &pb.CreateMessageResult{
+			Role:    pb.Role_ASSISTANT,
+			Content: &pb.ContentBlock{ContentType: &pb.ContentBlock_Text{Text: &pb.TextContent{Text: summary}}},
+			Model:   "test-model",
+		}},
+	})
+	require.NoErrorf(t, err, "error on stream.Send")
+
+	callToolResult := <-results
+	require.NotNil(t, callToolResult, "expected a result from CallMethod")
+	assert.False(callToolResult.GetIsError())
+	require.Len(t, callToolResult.GetContent(), 1)
+	assert.Equal(summary, callToolResult.GetContent()[0].GetText().GetText())
+
+	// a client can also turn the server down, which the tool reports as its error
+	results = summarize(text)
+
+	serverRequest, err = stream.Recv()
+	require.NoErrorf(t, err, "error on stream.Recv")
+	err = stream.Send(&pb.ClientResponse{
+		RequestId: serverRequest.GetRequestId(),
+		Response:  &pb.ClientResponse_Error{Error: &pb.ClientError{Code: -1, Message: "user rejected sampling"}},
+	})
+	require.NoErrorf(t, err, "error on stream.Send")
+
+	callToolResult = <-results
+	require.NotNil(t, callToolResult, "expected a result from CallMethod")
+	assert.True(callToolResult.GetIsError())
+	require.Len(t, callToolResult.GetContent(), 1)
+	assert.Contains(callToolResult.GetContent()[0].GetText().GetText(), "user rejected sampling")
+}
+
+func doGrpcProxyCatalogTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doProxyInitialize")
+
+	// cancelling is how a client stops watching, the timeout keeps us from hanging
+	streamCtx, cancel := context.WithTimeout(sessionCtx, 5*time.Second)
+	defer cancel()
+
+	stream, err := mcpGrpcClient.WatchCatalog(streamCtx, &pb.WatchCatalogRequest{})
+	require.NoErrorf(t, err, "error with WatchCatalog")
+	// the header means the proxy is listening for changes
+	_, err = stream.Header()
+	require.NoErrorf(t, err, "error waiting on the WatchCatalog header")
+
+	callToolResult, err := mcpGrpcClient.CallMethod(sessionCtx, &pb.CallToolRequest{Name: examplemcp.TOOL_ENABLE_UPPER})
+	require.NoErrorf(t, err, "error with CallMethod")
+	require.False(t, callToolResult.GetIsError(), "unexpected error enabling %s", examplemcp.TOOL_UPPER)
+
+	// the example server gives this session a tool of its own and says its tools changed
+	change, err := stream.Recv()
+	require.NoErrorf(t, err, "error on stream.Recv")
+	require.NotNil(t, change.GetTools(), "expected a change to the tools")
+
+	var toolNames []string
+	for _, tool := range change.GetTools().GetTools() {
+		toolNames = append(toolNames, tool.GetName())
+	}
+	sort.Strings(toolNames)
+	expected := append(examplemcp.GetProvidedToolNames(), examplemcp.TOOL_UPPER)
+	sort.Strings(expected)
+	assert.Equal(expected, toolNames)
+}
+
+func doGrpcProxyProgressTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doProxyInitialize")
+
+	const steps = 3
+	stream, err := mcpGrpcClient.CallToolWithProgress(sessionCtx, &pb.CallToolRequest{
+		Name:      examplemcp.TOOL_COUNT_STEPS,
+		Arguments: map[string]*structpb.Value{examplemcp.PARAM_STEPS: structpb.NewNumberValue(steps)},
+	})
+	require.NoErrorf(t, err, "error with CallToolWithProgress")
+
+	var progress []*pb.ProgressNotification
+	var logs []*pb.LoggingMessageNotification
+	var result *pb.CallToolResult
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoErrorf(t, err, "error on stream.Recv")
+		require.Nil(t, result, "nothing should follow the result")
+
+		switch e := event.GetEvent().(type) {
+		case *pb.CallToolProgress_Progress:
+			progress = append(progress, e.Progress)
+		case *pb.CallToolProgress_Log:
+			logs = append(logs, e.Log)
+		case *pb.CallToolProgress_Result:
+			result = e.Result
+		}
+	}
+
+	require.Len(t, progress, steps)
+	for i, p := range progress {
+		assert.Equal(float64(i+1), p.GetProgress())
+		assert.Equal(float64(steps), p.GetTotal())
+		assert.NotEmpty(p.GetProgressToken().GetStringValue())
+	}
+	require.Len(t, logs, steps)
+	assert.Equal(pb.LoggingLevel_INFO, logs[0].GetLevel())
+	assert.Equal(examplemcp.TOOL_COUNT_STEPS, logs[0].GetLogger())
+	assert.Equal("step 1", logs[0].GetData().GetStringValue())
+
+	require.NotNil(t, result)
+	require.Len(t, result.GetContent(), 1)
+	assert.Equal(fmt.Sprintf("%d", steps), result.GetContent()[0].GetText().GetText())
 }
 
 func doGrpcProxyPromptTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
@@ -203,12 +497,74 @@ func doGrpcProxyPromptTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolC
 
 	assert.Equalf(promptNamesExpected, promptNamesProvided, "prompt names mis matched")
 
-	// TODO make some assertions about the prompt
-	_, err = mcpGrpcClient.GetPrompt(sessionCtx, &pb.GetPromptRequest{Name: promptNamesExpected[0]})
-	assert.NoErrorf(err, "error with GetPrompt")
+	greetPrompt := listPromptResult.GetPrompts()[0]
+	require.Len(t, greetPrompt.GetArguments(), 1)
+	assert.Equal(examplemcp.PARAM_WHOM, greetPrompt.GetArguments()[0].GetName())
+	assert.True(greetPrompt.GetArguments()[0].GetRequired())
+
+	getPromptResult, err := mcpGrpcClient.GetPrompt(sessionCtx, &pb.GetPromptRequest{
+		Name:      promptNamesExpected[0],
+		Arguments: map[string]string{examplemcp.PARAM_WHOM: "bob"},
+	})
+	require.NoErrorf(t, err, "error with GetPrompt")
+	assert.Equal("A simple prompt to greet someone", getPromptResult.GetDescription())
+	require.Len(t, getPromptResult.GetMessages(), 1)
+	assert.Equal(pb.Role_USER, getPromptResult.GetMessages()[0].GetRole())
+	assert.Equal("What's up, bob?", getPromptResult.GetMessages()[0].GetContent().GetText().GetText())
 
 }
 
+func doGrpcProxyCompleteTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doMcpInitialize")
+
+	promptRef := &pb.Reference{RefOneof: &pb.Reference_Prompt{
+		Prompt: &pb.PromptReference{Name: examplemcp.PROMPT_GREET},
+	}}
+	completeResult, err := mcpGrpcClient.Complete(sessionCtx, &pb.CompleteRequest{
+		Ref:      promptRef,
+		Argument: &pb.CompletionArgument{Name: examplemcp.PARAM_WHOM, Value: "b"},
+	})
+	require.NoErrorf(t, err, "error with prompt Complete")
+	assert.Equal([]string{"bob", "bobby"}, completeResult.GetCompletion().GetValues())
+	assert.EqualValues(2, completeResult.GetCompletion().GetTotal())
+
+	templateRef := &pb.Reference{RefOneof: &pb.Reference_ResourceTemplate{
+		ResourceTemplate: &pb.ResourceTemplateReference{Uri: examplemcp.RESOURCE_URI_TEMPLATE_GREETING},
+	}}
+	completeResult, err = mcpGrpcClient.Complete(sessionCtx, &pb.CompleteRequest{
+		Ref:      templateRef,
+		Argument: &pb.CompletionArgument{Name: examplemcp.PARAM_WHOM, Value: "c"},
+	})
+	require.NoErrorf(t, err, "error with resource template Complete")
+	assert.Equal([]string{"carol"}, completeResult.GetCompletion().GetValues())
+
+	// an empty value gets everything
+	completeResult, err = mcpGrpcClient.Complete(sessionCtx, &pb.CompleteRequest{
+		Ref:      templateRef,
+		Argument: &pb.CompletionArgument{Name: examplemcp.PARAM_WHOM},
+	})
+	require.NoErrorf(t, err, "error with resource template Complete")
+	assert.Equal(examplemcp.CompletionNames, completeResult.GetCompletion().GetValues())
+
+	// the server says so when it has nothing to complete
+	_, err = mcpGrpcClient.Complete(sessionCtx, &pb.CompleteRequest{
+		Ref: &pb.Reference{RefOneof: &pb.Reference_Prompt{
+			Prompt: &pb.PromptReference{Name: "noSuchPrompt"},
+		}},
+		Argument: &pb.CompletionArgument{Name: examplemcp.PARAM_WHOM},
+	})
+	assert.Equal(codes.Aborted, status.Code(err))
+
+	// and without a ref there's nothing to ask it
+	_, err = mcpGrpcClient.Complete(sessionCtx, &pb.CompleteRequest{
+		Argument: &pb.CompletionArgument{Name: examplemcp.PARAM_WHOM},
+	})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func SetupAsyncMcpAndProxy(mcpServerName string) (pb.ModelContextProtocolClient, func(), error) {
 
 	closeLine := &CloseLine{}
@@ -314,12 +670,97 @@ func doGrpcProxyStreamTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolC
 
 }
 
+func doGrpcProxyListAllTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	assert := assert.New(t)
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doMcpInitialize")
+
+	toolStream, err := mcpGrpcClient.ListAllTools(sessionCtx, &pb.ListAllRequest{})
+	require.NoErrorf(t, err, "error with ListAllTools")
+	var toolsFound []string
+	for {
+		tool, err := toolStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoErrorf(t, err, "error on ListAllTools Recv")
+		toolsFound = append(toolsFound, tool.GetName())
+	}
+	assert.ElementsMatch(examplemcp.GetProvidedToolNames(), toolsFound)
+
+	promptStream, err := mcpGrpcClient.ListAllPrompts(sessionCtx, &pb.ListAllRequest{})
+	require.NoErrorf(t, err, "error with ListAllPrompts")
+	var promptsFound []string
+	for {
+		prompt, err := promptStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoErrorf(t, err, "error on ListAllPrompts Recv")
+		promptsFound = append(promptsFound, prompt.GetName())
+	}
+	assert.ElementsMatch(examplemcp.GetProvidedPrompts(), promptsFound)
+
+	resourceStream, err := mcpGrpcClient.ListAllResources(sessionCtx, &pb.ListAllRequest{})
+	require.NoErrorf(t, err, "error with ListAllResources")
+	var resourcesFound []string
+	for {
+		resource, err := resourceStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoErrorf(t, err, "error on ListAllResources Recv")
+		resourcesFound = append(resourcesFound, resource.GetUri())
+	}
+	assert.Contains(resourcesFound, examplemcp.RESOURCE_URI_STATIC)
+
+	templateStream, err := mcpGrpcClient.ListAllResourceTemplates(sessionCtx, &pb.ListAllRequest{})
+	require.NoErrorf(t, err, "error with ListAllResourceTemplates")
+	var templatesFound []string
+	for {
+		template, err := templateStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoErrorf(t, err, "error on ListAllResourceTemplates Recv")
+		templatesFound = append(templatesFound, template.GetUriTemplate())
+	}
+	assert.Contains(templatesFound, examplemcp.RESOURCE_URI_TEMPLATE_GREETING)
+}
+
+func doGrpcProxyTerminateTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
+
+	// without a session there is nothing to end
+	_, err := mcpGrpcClient.Terminate(t.Context(), &pb.TerminateRequest{})
+	require.Error(t, err)
+
+	sessionCtx, err := doProxyInitialize(t.Context(), mcpGrpcClient)
+	require.NoErrorf(t, err, "error with doMcpInitialize")
+
+	_, err = mcpGrpcClient.Terminate(sessionCtx, &pb.TerminateRequest{})
+	require.NoErrorf(t, err, "error with Terminate")
+
+	// the server has forgotten the session, so it's no good for anything else
+	_, err = mcpGrpcClient.Ping(sessionCtx, &pb.PingRequest{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func doMcpClientTests(t *testing.T, mcpGrpcClient pb.ModelContextProtocolClient) {
 
 	doGrpcProxyTests(t, mcpGrpcClient)
 	doGrpcProxyToolTests(t, mcpGrpcClient)
+	doGrpcProxyContentTests(t, mcpGrpcClient)
 	doGrpcProxyPromptTests(t, mcpGrpcClient)
+	doGrpcProxyCompleteTests(t, mcpGrpcClient)
 	doGrpcProxyResourceTests(t, mcpGrpcClient)
+	doGrpcProxySubscribeTests(t, mcpGrpcClient)
+	doGrpcProxyLoggingTests(t, mcpGrpcClient)
+	doGrpcProxySamplingTests(t, mcpGrpcClient)
+	doGrpcProxyCatalogTests(t, mcpGrpcClient)
+	doGrpcProxyProgressTests(t, mcpGrpcClient)
 	doGrpcProxyStreamTests(t, mcpGrpcClient)
+	doGrpcProxyListAllTests(t, mcpGrpcClient)
+	doGrpcProxyTerminateTests(t, mcpGrpcClient)
 
 }
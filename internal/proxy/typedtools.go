@@ -0,0 +1,170 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"grpc2mcp/internal/mcpconst"
+	"grpc2mcp/internal/toolproto"
+	mcp "grpc2mcp/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// typedTools is a generated per tool service, served alongside ModelContextProtocol.
+type typedTools struct {
+	file       *toolproto.File
+	descriptor protoreflect.FileDescriptor
+}
+
+// DescribeTools opens its own session with the MCP server, lists the server's tools and
+// generates a typed proto file for them.
+func (s *Server) DescribeTools(ctx context.Context, opts toolproto.Options) (*toolproto.File, error) {
+
+	sessionID, err := s.openSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, mcpconst.MCP_SESSION_ID_HEADER, sessionID)
+	// the session is only for listing, don't leave it behind on the server. not every
+	// server lets us end it, which is fine.
+	defer func() {
+		err := s.terminateSession(context.WithoutCancel(ctx))
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			log.Printf("could not end tool listing session: %v", err)
+		}
+	}()
+
+	var tools []toolproto.Tool
+	listRequest := &mcp.ListToolsRequest{}
+	for {
+		var result struct {
+			Tools      []toolproto.Tool `json:"tools"`
+			NextCursor string           `json:"nextCursor"`
+		}
+		if err := s.doRpcCall(ctx, listRequest, mcpconst.ToolsList, &result); err != nil {
+			return nil, err
+		}
+		tools = append(tools, result.Tools...)
+		if result.NextCursor == "" {
+			break
+		}
+		listRequest.Cursor = proto.String(result.NextCursor)
+	}
+
+	return toolproto.NewFile(opts, tools)
+}
+
+// ServeTypedTools generates a typed service for the MCP server's tools, which the gRPC
+// server then serves next to ModelContextProtocol. It has to be called before starting.
+// Calls to it still need the mcp-session-id header from Initialize.
+func (s *Server) ServeTypedTools(ctx context.Context, opts toolproto.Options) (*toolproto.File, error) {
+
+	file, err := s.DescribeTools(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := file.Descriptor()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build typed tools descriptor: %v", err)
+	}
+	s.typedTools = &typedTools{file: file, descriptor: descriptor}
+	return file, nil
+}
+
+// serviceDesc describes the generated service to grpc, with a handler per RPC.
+func (t *typedTools) serviceDesc(s *Server) *grpc.ServiceDesc {
+
+	service := t.descriptor.Services().Get(0)
+	desc := &grpc.ServiceDesc{
+		ServiceName: string(service.FullName()),
+		HandlerType: (*any)(nil),
+		Metadata:    t.descriptor.Path(),
+	}
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: string(method.Name()),
+			Handler:    s.typedToolHandler(t.file.ToolNames[string(method.Name())], method),
+		})
+	}
+	return desc
+}
+
+func (s *Server) typedToolHandler(toolName string, method protoreflect.MethodDescriptor) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+
+	fullMethod := "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+	call := func(ctx context.Context, req any) (any, error) {
+		return s.callTypedTool(ctx, toolName, method, req.(*dynamicpb.Message))
+	}
+
+	return func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := dynamicpb.NewMessage(method.Input())
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(ctx, in)
+		}
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: s, FullMethod: fullMethod}, call)
+	}
+}
+
+// callTypedTool calls the tool with the request's fields as arguments. Tools with an
+// outputSchema have their structured content, or failing that the JSON text content
+// the spec asks them to include too, decoded into the response message.
+func (s *Server) callTypedTool(ctx context.Context, toolName string, method protoreflect.MethodDescriptor, in *dynamicpb.Message) (proto.Message, error) {
+
+	arguments, err := structpb.NewStruct(toolproto.ToArguments(in))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to convert request to arguments: %v", err)
+	}
+
+	result, err := s.doCallMethodRpc(ctx, &mcp.CallToolRequest{Name: toolName, Arguments: arguments.GetFields()})
+	if err != nil {
+		return nil, err
+	}
+
+	var texts []string
+	for _, content := range result.GetContent() {
+		if text := content.GetText(); text != nil {
+			texts = append(texts, text.GetText())
+		}
+	}
+
+	if result.GetIsError() {
+		return nil, status.Errorf(codes.Aborted, "tool %s returned an error: %s", toolName, strings.Join(texts, "\n"))
+	}
+
+	if method.Output().FullName() == (&mcp.CallToolResult{}).ProtoReflect().Descriptor().FullName() {
+		return result, nil
+	}
+
+	var structured []byte
+	if result.GetStructuredContent() != nil {
+		structured, err = json.Marshal(result.GetStructuredContent())
+	} else if len(texts) > 0 {
+		structured = []byte(texts[0])
+	} else {
+		err = status.Errorf(codes.Internal, "tool %s returned no structured content", toolName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := dynamicpb.NewMessage(method.Output())
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(structured, out); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode structured content from tool %s: %v", toolName, err)
+	}
+	return out, nil
+}
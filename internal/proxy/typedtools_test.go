@@ -0,0 +1,104 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/toolproto"
+	"grpc2mcp/pb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestTypedTools(t *testing.T) {
+
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(examplemcp.RunExampleMcpServer(t.Name(), "/mcp"))
+	defer ts.Close()
+
+	s, err := NewServer(ts.URL)
+	require.NoError(t, err)
+	file, err := s.ServeTypedTools(t.Context(), toolproto.Options{Package: "mcp.tools", Service: "Tools"})
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	serverCancel, err := s.StartProxyToListenerAsync(lis)
+	require.NoError(t, err)
+	defer serverCancel()
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	fd, err := file.Descriptor()
+	require.NoError(t, err)
+	service := fd.Services().ByName("Tools")
+	require.NotNil(t, service)
+	assert.Equal(len(examplemcp.GetProvidedToolNames()), service.Methods().Len())
+
+	invoke := func(ctx context.Context, rpcName string, requestJson string) (*dynamicpb.Message, error) {
+		method := service.Methods().ByName(protoreflect.Name(rpcName))
+		require.NotNil(t, method, rpcName)
+		in := dynamicpb.NewMessage(method.Input())
+		require.NoError(t, protojson.Unmarshal([]byte(requestJson), in))
+		out := dynamicpb.NewMessage(method.Output())
+		err := conn.Invoke(ctx, "/mcp.tools.Tools/"+rpcName, in, out)
+		return out, err
+	}
+
+	// like the untyped methods, these need a session
+	_, err = invoke(t.Context(), "Add", `{"a": 1, "b": 2}`)
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+
+	sessionCtx, err := doProxyInitialize(t.Context(), pb.NewModelContextProtocolClient(conn))
+	require.NoError(t, err)
+
+	// tools without an outputSchema return a CallToolResult, zeros still get sent
+	out, err := invoke(sessionCtx, "Add", `{"a": 0, "b": 2}`)
+	require.NoError(t, err)
+	result := &pb.CallToolResult{}
+	b, err := protojson.Marshal(out)
+	require.NoError(t, err)
+	require.NoError(t, protojson.Unmarshal(b, result))
+	require.Len(t, result.GetContent(), 1)
+	assert.Equal("2", result.GetContent()[0].GetText().GetText())
+
+	// tools with one get their own response message
+	out, err = invoke(sessionCtx, "WordCount", `{"s": "three little words"}`)
+	require.NoError(t, err)
+	b, err = protojson.Marshal(out)
+	require.NoError(t, err)
+	assert.JSONEq(`{"words": "3", "characters": "18"}`, string(b))
+
+	// tool errors become grpc errors
+	_, err = invoke(sessionCtx, "Lower", `{}`)
+	assert.Equal(codes.Aborted, status.Code(err))
+
+	// and reflection knows about the generated service
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "mcp.tools.Tools"},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Nil(resp.GetErrorResponse())
+	assert.NotEmpty(resp.GetFileDescriptorResponse().GetFileDescriptorProto())
+}
@@ -0,0 +1,168 @@
+package reverse
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// reflection itself isn't worth publishing as tools
+const reflectionServicePrefix = "grpc.reflection."
+
+// ServicesFromReflection asks the server behind conn which services it offers, and
+// pulls down the file descriptors needed to describe them, via server reflection.
+func ServicesFromReflection(ctx context.Context, conn grpc.ClientConnInterface) ([]protoreflect.ServiceDescriptor, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opening reflection stream: %w", err)
+	}
+	defer stream.CloseSend()
+
+	ask := func(req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("reflection error %d: %s", errResp.GetErrorCode(), errResp.GetErrorMessage())
+		}
+		return resp, nil
+	}
+
+	resp, err := ask(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing services: %w", err)
+	}
+
+	var serviceNames []string
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		if !strings.HasPrefix(svc.GetName(), reflectionServicePrefix) {
+			serviceNames = append(serviceNames, svc.GetName())
+		}
+	}
+
+	fileProtos := map[string]*descriptorpb.FileDescriptorProto{}
+	addFiles := func(resp *rpb.ServerReflectionResponse) error {
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fdp := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, fdp); err != nil {
+				return fmt.Errorf("decoding file descriptor: %w", err)
+			}
+			fileProtos[fdp.GetName()] = fdp
+		}
+		return nil
+	}
+
+	for _, name := range serviceNames {
+		resp, err := ask(&rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resolving service %s: %w", name, err)
+		}
+		if err := addFiles(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	// servers only send each file once per stream, so chase down any dependency
+	// that we still haven't seen
+	for missing := missingDependencies(fileProtos); len(missing) > 0; missing = missingDependencies(fileProtos) {
+		for _, filename := range missing {
+			resp, err := ask(&rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: filename},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("resolving file %s: %w", filename, err)
+			}
+			if err := addFiles(resp); err != nil {
+				return nil, err
+			}
+			if _, ok := fileProtos[filename]; !ok {
+				return nil, fmt.Errorf("server did not return file %s", filename)
+			}
+		}
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, fdp := range fileProtos {
+		fds.File = append(fds.File, fdp)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("building descriptors: %w", err)
+	}
+
+	services := make([]protoreflect.ServiceDescriptor, 0, len(serviceNames))
+	for _, name := range serviceNames {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("finding service %s: %w", name, err)
+		}
+		svc, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a service", name)
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+func missingDependencies(fileProtos map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+	for _, fdp := range fileProtos {
+		for _, dep := range fdp.GetDependency() {
+			if _, ok := fileProtos[dep]; !ok {
+				missing = append(missing, dep)
+			}
+		}
+	}
+	return missing
+}
+
+// ServicesFromDescriptorSet reads every service out of a serialized FileDescriptorSet,
+// eg one written by `protoc --include_imports --descriptor_set_out=...`
+func ServicesFromDescriptorSet(path string) ([]protoreflect.ServiceDescriptor, error) {
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return nil, fmt.Errorf("decoding descriptor set %s: %w", path, err)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("building descriptors from %s: %w", path, err)
+	}
+
+	var services []protoreflect.ServiceDescriptor
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			svc := fd.Services().Get(i)
+			if !strings.HasPrefix(string(svc.FullName()), reflectionServicePrefix) {
+				services = append(services, svc)
+			}
+		}
+		return true
+	})
+	return services, nil
+}
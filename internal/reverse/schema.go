@@ -0,0 +1,114 @@
+package reverse
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageSchema describes, as JSON schema, what protojson will accept for md. Messages
+// that contain themselves are only expanded once, deeper down they're plain objects.
+func messageSchema(md protoreflect.MessageDescriptor) map[string]any {
+	return messageSchemaVisiting(md, map[protoreflect.FullName]bool{})
+}
+
+func messageSchemaVisiting(md protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]bool) map[string]any {
+
+	if wkt := wellKnownSchema(md); wkt != nil {
+		return wkt
+	}
+	if visiting[md.FullName()] {
+		return map[string]any{"type": "object"}
+	}
+	visiting[md.FullName()] = true
+	defer delete(visiting, md.FullName())
+
+	properties := map[string]any{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(fd, visiting)
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) map[string]any {
+	switch {
+	case fd.IsMap():
+		// protojson keys are always strings, whatever the key kind
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": singularSchema(fd.MapValue(), visiting),
+		}
+	case fd.IsList():
+		return map[string]any{
+			"type":  "array",
+			"items": singularSchema(fd, visiting),
+		}
+	default:
+		return singularSchema(fd, visiting)
+	}
+}
+
+func singularSchema(fd protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]bool) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "integer"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]any, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchemaVisiting(fd.Message(), visiting)
+	}
+	return map[string]any{}
+}
+
+// wellKnownSchema covers the google.protobuf types that protojson gives special
+// formats to, nil for everything else.
+func wellKnownSchema(md protoreflect.MessageDescriptor) map[string]any {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return map[string]any{"type": "string"}
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object"}
+	case "google.protobuf.ListValue":
+		return map[string]any{"type": "array"}
+	case "google.protobuf.Value":
+		return map[string]any{}
+	case "google.protobuf.Any":
+		return map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"@type": map[string]any{"type": "string"}},
+		}
+	case "google.protobuf.BoolValue":
+		return map[string]any{"type": "boolean"}
+	case "google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		return map[string]any{"type": "integer"}
+	case "google.protobuf.FloatValue", "google.protobuf.DoubleValue":
+		return map[string]any{"type": "number"}
+	case "google.protobuf.StringValue":
+		return map[string]any{"type": "string"}
+	case "google.protobuf.BytesValue":
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package reverse runs grpc2mcp backwards, publishing the unary methods of a gRPC
+// service as tools on an MCP server.
+package reverse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const SERVER_VERSION = "0.0.0"
+
+// NewMcpServer returns an MCP server with one tool per unary method of services, each
+// of which invokes its method over conn. Streaming methods are skipped.
+func NewMcpServer(serverName string, conn grpc.ClientConnInterface, services []protoreflect.ServiceDescriptor) (*server.MCPServer, error) {
+
+	s := server.NewMCPServer(serverName, SERVER_VERSION, server.WithToolCapabilities(false))
+
+	for _, svc := range services {
+		methods := svc.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			md := methods.Get(i)
+			if md.IsStreamingClient() || md.IsStreamingServer() {
+				log.Printf("skipping streaming method %s", md.FullName())
+				continue
+			}
+			tool, err := methodTool(md)
+			if err != nil {
+				return nil, err
+			}
+			s.AddTool(tool, methodHandler(conn, md))
+		}
+	}
+	return s, nil
+}
+
+// NewHandler serves NewMcpServer over streamable http at uri.
+func NewHandler(serverName string, uri string, conn grpc.ClientConnInterface, services []protoreflect.ServiceDescriptor) (http.Handler, error) {
+
+	s, err := NewMcpServer(serverName, conn, services)
+	if err != nil {
+		return nil, err
+	}
+	return server.NewStreamableHTTPServer(s, server.WithEndpointPath(uri)), nil
+}
+
+// ToolName is the name a method is published under, its full name with dots swapped
+// for underscores as MCP clients tend to be picky about tool names.
+func ToolName(md protoreflect.MethodDescriptor) string {
+	return strings.ReplaceAll(string(md.FullName()), ".", "_")
+}
+
+func methodTool(md protoreflect.MethodDescriptor) (mcp.Tool, error) {
+
+	schema, err := json.Marshal(messageSchema(md.Input()))
+	if err != nil {
+		return mcp.Tool{}, fmt.Errorf("schema for %s: %w", md.FullName(), err)
+	}
+
+	description := strings.TrimSpace(md.ParentFile().SourceLocations().ByDescriptor(md).LeadingComments)
+	if description == "" {
+		description = fmt.Sprintf("Calls the gRPC method %s", grpcMethodPath(md))
+	}
+	return mcp.NewToolWithRawSchema(ToolName(md), description, schema), nil
+}
+
+func grpcMethodPath(md protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name())
+}
+
+func methodHandler(conn grpc.ClientConnInterface, md protoreflect.MethodDescriptor) server.ToolHandlerFunc {
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		args, err := json.Marshal(request.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+		in := dynamicpb.NewMessage(md.Input())
+		if err := protojson.Unmarshal(args, in); err != nil {
+			return mcp.NewToolResultErrorf("invalid arguments for %s: %v", md.FullName(), err), nil
+		}
+
+		out := dynamicpb.NewMessage(md.Output())
+		if err := conn.Invoke(ctx, grpcMethodPath(md), in, out); err != nil {
+			st := status.Convert(err)
+			return mcp.NewToolResultErrorf("%s: %s", st.Code(), st.Message()), nil
+		}
+
+		result, err := protojson.Marshal(out)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(string(result)), nil
+	}
+}
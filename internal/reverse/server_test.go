@@ -0,0 +1,125 @@
+package reverse
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"grpc2mcp/pb"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func startHealthServer(t *testing.T) *grpc.ClientConn {
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("up", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	bufDialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(bufDialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestReflectionTools(t *testing.T) {
+
+	assert := assert.New(t)
+	ctx := context.Background()
+	conn := startHealthServer(t)
+
+	services, err := ServicesFromReflection(ctx, conn)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal("grpc.health.v1.Health", string(services[0].FullName()))
+
+	mcpServer, err := NewMcpServer(t.Name(), conn, services)
+	require.NoError(t, err)
+	mcpClient, err := client.NewInProcessClient(mcpServer)
+	require.NoError(t, err)
+	defer mcpClient.Close()
+
+	_, err = mcpClient.Initialize(ctx, mcp.InitializeRequest{})
+	require.NoError(t, err)
+
+	toolsResult, err := mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	tools := map[string]mcp.Tool{}
+	for _, tool := range toolsResult.Tools {
+		tools[tool.Name] = tool
+	}
+	assert.Contains(tools, "grpc_health_v1_Health_Check")
+	assert.Contains(tools, "grpc_health_v1_Health_List")
+	assert.NotContains(tools, "grpc_health_v1_Health_Watch", "streaming methods aren't tools")
+
+	toolJson, err := json.Marshal(tools["grpc_health_v1_Health_Check"])
+	require.NoError(t, err)
+	assert.JSONEq(`{"type": "object", "properties": {"service": {"type": "string"}}}`,
+		string(mustField(t, toolJson, "inputSchema")))
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = "grpc_health_v1_Health_Check"
+	callRequest.Params.Arguments = map[string]any{"service": "up"}
+	callResult, err := mcpClient.CallTool(ctx, callRequest)
+	require.NoError(t, err)
+	require.False(t, callResult.IsError)
+	require.Len(t, callResult.Content, 1)
+	assert.JSONEq(`{"status": "SERVING"}`, callResult.Content[0].(mcp.TextContent).Text)
+
+	// errors from the grpc server come back as tool errors
+	callRequest.Params.Arguments = map[string]any{"service": "nonesuch"}
+	callResult, err = mcpClient.CallTool(ctx, callRequest)
+	require.NoError(t, err)
+	assert.True(callResult.IsError)
+	assert.Contains(callResult.Content[0].(mcp.TextContent).Text, "NotFound")
+
+	// as do arguments that don't fit the request message
+	callRequest.Params.Arguments = map[string]any{"nonesuch": "up"}
+	callResult, err = mcpClient.CallTool(ctx, callRequest)
+	require.NoError(t, err)
+	assert.True(callResult.IsError)
+}
+
+func mustField(t *testing.T, b []byte, name string) json.RawMessage {
+	fields := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(b, &fields))
+	require.Contains(t, fields, name)
+	return fields[name]
+}
+
+func TestMessageSchema(t *testing.T) {
+
+	assert := assert.New(t)
+
+	md := pb.File_mcp_proto.Messages().ByName("CallToolRequest")
+	require.NotNil(t, md)
+
+	schema, err := json.Marshal(messageSchema(md))
+	require.NoError(t, err)
+	assert.JSONEq(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"arguments": {"type": "object", "additionalProperties": {}},
+			"Meta": {"type": "object"}
+		}
+	}`, string(schema))
+}
@@ -0,0 +1,562 @@
+package stdio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"grpc2mcp/internal/mcpconst"
+)
+
+const (
+	// the largest single JSON-RPC message we'll read from the child
+	maxMessageSize = 16 * 1024 * 1024
+
+	// how long the child gets to exit on its own after we close its stdin
+	closeTimeout = 2 * time.Second
+
+	// how many unsolicited messages a slow listener can fall behind before we drop them
+	listenerBuffer = 64
+)
+
+var errTransportClosed = errors.New("stdio transport is closed")
+
+// Transport is an http.RoundTripper which speaks the MCP stdio transport to a child
+// process instead of going to the network. Plugging it into the proxy's http.Client
+// leaves every other code path, headers and SSE parsing included, untouched:
+//
+//   - POSTed requests are written to the child's stdin as a line of JSON and the
+//     matching line from its stdout comes back as an application/json response.
+//     Ids are rewritten on the way through so concurrent calls can't collide.
+//   - POSTed notifications and responses are written through and answered with 202.
+//     Callers giving up on a request are cancelled with the child under our id.
+//   - a GET becomes an SSE stream of everything the child sends on its own, ie
+//     its notifications and requests.
+//
+// The child is started on first use and restarted on the next call if it dies. It only
+// ever has one session, so the first initialize is sent through and cached; later
+// ones are answered from the cache and the cached one is replayed to a restarted child.
+// Every caller therefore shares the first one's session: the capabilities and
+// clientInfo of later initializes never reach the child, which is logged when they
+// differ.
+type Transport struct {
+	command string
+	args    []string
+
+	sessionID string
+
+	// startMu serializes starting, and after a crash re-initializing, the child
+	startMu sync.Mutex
+
+	// writeMu serializes writes to the child's stdin
+	writeMu sync.Mutex
+
+	mu                sync.Mutex
+	child             *child
+	closed            bool
+	nextID            int64
+	pending           map[int64]chan []byte
+	listeners         map[chan []byte]struct{}
+	initializeRequest []byte
+	initializeResult  json.RawMessage
+	initializedSent   bool
+}
+
+// child is one run of the MCP server process.
+type child struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	done  chan struct{}
+}
+
+// NewTransport returns a Transport which runs command with args. Nothing is started
+// until the first request.
+func NewTransport(command string, args ...string) *Transport {
+	sessionBytes := make([]byte, 16)
+	_, _ = rand.Read(sessionBytes)
+
+	return &Transport{
+		command:   command,
+		args:      args,
+		sessionID: "stdio-" + hex.EncodeToString(sessionBytes),
+		pending:   map[int64]chan []byte{},
+		listeners: map[chan []byte]struct{}{},
+	}
+}
+
+// URL is a placeholder for the proxy to address requests to. They never leave the process.
+func (t *Transport) URL() string {
+	return "stdio:///" + filepath.Base(t.command)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost:
+		return t.post(req)
+	case http.MethodGet:
+		return t.listen(req)
+	default:
+		return t.newResponse(req, http.StatusMethodNotAllowed, "text/plain",
+			[]byte(fmt.Sprintf("%s is not supported over stdio", req.Method))), nil
+	}
+}
+
+// Close stops the child, first by closing its stdin and then, if it takes too long
+// to exit, by killing it. The transport can't be used afterwards.
+func (t *Transport) Close() error {
+	t.mu.Lock()
+	t.closed = true
+	c := t.child
+	t.mu.Unlock()
+
+	if c == nil {
+		return nil
+	}
+
+	_ = c.stdin.Close()
+	select {
+	case <-c.done:
+	case <-time.After(closeTimeout):
+		_ = c.cmd.Process.Kill()
+		<-c.done
+	}
+	return nil
+}
+
+func (t *Transport) post(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	var envelope struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return t.newResponse(req, http.StatusBadRequest, "text/plain", []byte("request body is not valid json")), nil
+	}
+
+	switch {
+	case envelope.Method == string(mcpconst.Initialize):
+		return t.initialize(req, body, envelope.ID)
+
+	case envelope.Method == string(mcpconst.NotificationsCancelled):
+		// these name the caller's id, which the child never saw. call already sent the
+		// child its own when the caller gave up
+		return t.newResponse(req, http.StatusAccepted, "", nil), nil
+
+	case envelope.ID == nil || envelope.Method == "":
+		// notifications, and responses to requests the child made, need no answer
+		return t.notify(req, body, envelope.Method)
+	}
+
+	c, err := t.ensureChild(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := t.call(req.Context(), c, body, envelope.ID)
+	if err != nil {
+		return nil, err
+	}
+	return t.newResponse(req, http.StatusOK, "application/json", respBody), nil
+}
+
+// initialize sends the first initialize through to the child and answers every one
+// after it with the cached result, since the child only ever has the one session.
+func (t *Transport) initialize(req *http.Request, body []byte, id json.RawMessage) (*http.Response, error) {
+	t.mu.Lock()
+	initializeResult := t.initializeResult
+	t.mu.Unlock()
+
+	if initializeResult == nil {
+		c, err := t.ensureChild(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, err := t.call(req.Context(), c, body, id)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(respBody, &resp); err != nil || resp.Result == nil {
+			// errors go back as is and don't get cached
+			return t.newResponse(req, http.StatusOK, "application/json", respBody), nil
+		}
+
+		t.mu.Lock()
+		t.initializeRequest = body
+		t.initializeResult = resp.Result
+		t.mu.Unlock()
+		initializeResult = resp.Result
+	} else {
+		t.mu.Lock()
+		initializeRequest := t.initializeRequest
+		t.mu.Unlock()
+		if !sameClient(initializeRequest, body) {
+			log.Printf("mcp server process %s has one session, initialized by an earlier client, "+
+				"so this initialize's capabilities and clientInfo are not passed on", t.command)
+		}
+	}
+
+	respBody, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  initializeResult,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal initialize response: %w", err)
+	}
+	return t.newResponse(req, http.StatusOK, "application/json", respBody), nil
+}
+
+// sameClient is whether two initialize requests name the same capabilities and
+// clientInfo.
+func sameClient(a, b []byte) bool {
+	var client [2]struct {
+		Params struct {
+			Capabilities json.RawMessage `json:"capabilities"`
+			ClientInfo   json.RawMessage `json:"clientInfo"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(a, &client[0]) != nil || json.Unmarshal(b, &client[1]) != nil {
+		return false
+	}
+	return bytes.Equal(compact(client[0].Params.Capabilities), compact(client[1].Params.Capabilities)) &&
+		bytes.Equal(compact(client[0].Params.ClientInfo), compact(client[1].Params.ClientInfo))
+}
+
+// compact is raw without insignificant whitespace, or raw itself if it isn't JSON.
+func compact(raw json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if json.Compact(&buf, raw) != nil {
+		return raw
+	}
+	return buf.Bytes()
+}
+
+func (t *Transport) notify(req *http.Request, body []byte, method string) (*http.Response, error) {
+	c, err := t.ensureChild(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if method == string(mcpconst.NotificationsInitialized) {
+		t.mu.Lock()
+		alreadySent := t.initializedSent
+		t.initializedSent = true
+		t.mu.Unlock()
+		if alreadySent {
+			return t.newResponse(req, http.StatusAccepted, "", nil), nil
+		}
+	}
+
+	if err := t.write(c, body); err != nil {
+		return nil, err
+	}
+	return t.newResponse(req, http.StatusAccepted, "", nil), nil
+}
+
+// listen hands back an SSE stream of the messages the child sends unprompted. It ends
+// when the request's context is done or the child exits.
+func (t *Transport) listen(req *http.Request) (*http.Response, error) {
+	if _, err := t.ensureChild(req.Context()); err != nil {
+		return nil, err
+	}
+
+	messages := make(chan []byte, listenerBuffer)
+	t.mu.Lock()
+	t.listeners[messages] = struct{}{}
+	t.mu.Unlock()
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer func() {
+			t.mu.Lock()
+			delete(t.listeners, messages)
+			t.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					_ = pw.Close()
+					return
+				}
+				if _, err := fmt.Fprintf(pw, "event: message\ndata: %s\n\n", msg); err != nil {
+					return
+				}
+			case <-req.Context().Done():
+				_ = pw.CloseWithError(req.Context().Err())
+				return
+			}
+		}
+	}()
+
+	return t.newStreamResponse(req, pr), nil
+}
+
+// call writes a request to the child under an id of our own and waits for its response,
+// which comes back carrying the caller's id again.
+func (t *Transport) call(ctx context.Context, c *child, body []byte, callerID json.RawMessage) ([]byte, error) {
+	t.mu.Lock()
+	t.nextID++
+	childID := t.nextID
+	respChan := make(chan []byte, 1)
+	t.pending[childID] = respChan
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, childID)
+		t.mu.Unlock()
+	}()
+
+	childBody, err := replaceID(body, childID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.write(c, childBody); err != nil {
+		return nil, err
+	}
+
+	select {
+	case respBody, ok := <-respChan:
+		if !ok {
+			return nil, fmt.Errorf("mcp server process %s exited before responding", t.command)
+		}
+		return replaceID(respBody, callerID)
+	case <-ctx.Done():
+		// the child only knows the request by our id, so the cancel has to come from us
+		t.cancel(c, childID, ctx.Err())
+		return nil, ctx.Err()
+	}
+}
+
+// cancel tells the child to stop working on the request it has under childID.
+func (t *Transport) cancel(c *child, childID int64, reason error) {
+	cancelled, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"method":  mcpconst.NotificationsCancelled,
+		"params":  map[string]any{"requestId": childID, "reason": reason.Error()},
+	})
+	if err == nil {
+		err = t.write(c, cancelled)
+	}
+	if err != nil {
+		log.Printf("failed to cancel request %d to mcp server process %s: %v", childID, t.command, err)
+	}
+}
+
+func (t *Transport) write(c *child, body []byte) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	line := append(bytes.TrimSpace(body), '\n')
+	if _, err := c.stdin.Write(line); err != nil {
+		return fmt.Errorf("failed to write to mcp server process %s: %w", t.command, err)
+	}
+	return nil
+}
+
+// ensureChild returns the running child, starting one if there isn't. A restarted
+// child is put through initialize again before anyone else gets to use it.
+func (t *Transport) ensureChild(ctx context.Context) (*child, error) {
+	t.startMu.Lock()
+	defer t.startMu.Unlock()
+
+	t.mu.Lock()
+	closed, c, initializeRequest := t.closed, t.child, t.initializeRequest
+	t.mu.Unlock()
+
+	if closed {
+		return nil, errTransportClosed
+	}
+	if c != nil {
+		return c, nil
+	}
+
+	c, err := t.start()
+	if err != nil {
+		return nil, err
+	}
+
+	if initializeRequest != nil {
+		log.Printf("re-initializing restarted mcp server process %s", t.command)
+		if _, err := t.call(ctx, c, initializeRequest, json.RawMessage("0")); err != nil {
+			return nil, fmt.Errorf("failed to re-initialize mcp server process %s: %w", t.command, err)
+		}
+		initialized := []byte(`{"jsonrpc":"2.0","method":"` + string(mcpconst.NotificationsInitialized) + `"}`)
+		if err := t.write(c, initialized); err != nil {
+			return nil, err
+		}
+	}
+
+	t.mu.Lock()
+	t.child = c
+	t.initializedSent = initializeRequest != nil
+	t.mu.Unlock()
+
+	return c, nil
+}
+
+func (t *Transport) start() (*child, error) {
+	cmd := exec.Command(t.command, t.args...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stdin for %s: %w", t.command, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stdout for %s: %w", t.command, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start mcp server process %s: %w", t.command, err)
+	}
+	log.Printf("started mcp server process %s %s (pid %d)", t.command, strings.Join(t.args, " "), cmd.Process.Pid)
+
+	c := &child{cmd: cmd, stdin: stdin, done: make(chan struct{})}
+	go t.read(c, stdout)
+	return c, nil
+}
+
+// read routes each line the child writes, responses to whoever is waiting on the id and
+// everything else to the listeners. Once the child exits it fails anyone still waiting
+// and ends the listener streams, and the next request starts a new child.
+func (t *Transport) read(c *child, stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		line := bytes.Clone(scanner.Bytes())
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var envelope struct {
+			ID     *int64 `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(line, &envelope); err != nil {
+			// could be a string id from a request of the child's own, let listeners have it
+			envelope.ID = nil
+		}
+
+		if envelope.Method == "" && envelope.ID != nil {
+			t.mu.Lock()
+			respChan, ok := t.pending[*envelope.ID]
+			delete(t.pending, *envelope.ID)
+			t.mu.Unlock()
+			if ok {
+				respChan <- line
+			}
+			continue
+		}
+
+		t.broadcast(line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Printf("failed to read from mcp server process %s: %v", t.command, err)
+	}
+	err := c.cmd.Wait()
+	log.Printf("mcp server process %s exited: %v", t.command, err)
+
+	t.mu.Lock()
+	if t.child == c {
+		t.child = nil
+	}
+	for id, respChan := range t.pending {
+		close(respChan)
+		delete(t.pending, id)
+	}
+	for messages := range t.listeners {
+		close(messages)
+		delete(t.listeners, messages)
+	}
+	t.mu.Unlock()
+
+	close(c.done)
+}
+
+func (t *Transport) broadcast(msg []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for messages := range t.listeners {
+		select {
+		case messages <- msg:
+		default:
+			log.Printf("dropping message from mcp server process %s for a slow listener", t.command)
+		}
+	}
+}
+
+// replaceID swaps the id of a JSON-RPC message, leaving everything else as it was.
+func replaceID(body []byte, id any) ([]byte, error) {
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jsonrpc message: %w", err)
+	}
+
+	idBytes, err := json.Marshal(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal jsonrpc id: %w", err)
+	}
+	msg["id"] = idBytes
+
+	return json.Marshal(msg)
+}
+
+func (t *Transport) newResponse(req *http.Request, statusCode int, contentType string, body []byte) *http.Response {
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	if contentType != "" {
+		resp.Header.Set("Content-Type", contentType)
+	}
+	// the child's one session is all there is, so every response is part of it
+	resp.Header.Set(mcpconst.MCP_SESSION_ID_HEADER, t.sessionID)
+	return resp
+}
+
+func (t *Transport) newStreamResponse(req *http.Request, body io.ReadCloser) *http.Response {
+	resp := t.newResponse(req, http.StatusOK, "text/event-stream", nil)
+	resp.Body = body
+	resp.ContentLength = -1
+	return resp
+}
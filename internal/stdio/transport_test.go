@@ -0,0 +1,157 @@
+package stdio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"grpc2mcp/internal/examplemcp"
+	"grpc2mcp/internal/jsonrpc"
+	"grpc2mcp/internal/mcpconst"
+
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const helperProcessEnv = "GRPC2MCP_STDIO_HELPER"
+
+// TestHelperProcess isn't a real test, it's the child process the other tests run:
+// this test binary re-executed to serve the example MCP server over stdio.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv(helperProcessEnv) != "1" {
+		t.Skip("only runs as a child process")
+	}
+	if err := examplemcp.RunExampleStdioServer(context.Background(), t.Name(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "stdio helper failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func newHelperTransport(t *testing.T) *Transport {
+	t.Setenv(helperProcessEnv, "1")
+	transport := NewTransport(os.Args[0], "-test.run=^TestHelperProcess$")
+	t.Cleanup(func() { transport.Close() })
+	return transport
+}
+
+func doTransportRequest(t *testing.T, client *http.Client, method mcpconst.JsonRpcMethod, params any) (*jsonrpc2.Response, *http.Response) {
+	req, err := jsonrpc.NewJSONRPCRequest(t.Context(), "stdio:///helper", method, params, nil, http.NewRequestWithContext)
+	require.NoError(t, err)
+
+	resp, httpResp, err := jsonrpc.DoRequest(t.Context(), client, req)
+	require.NoErrorf(t, err, "error with %s", method)
+	return resp, httpResp
+}
+
+func doAdd(t *testing.T, client *http.Client, a, b int) string {
+	params := map[string]any{"name": examplemcp.TOOL_ADD, "arguments": map[string]any{examplemcp.PARAM_A: a, examplemcp.PARAM_B: b}}
+	resp, _ := doTransportRequest(t, client, mcpconst.ToolsCall, params)
+	require.NotNil(t, resp)
+	require.Nil(t, resp.Error)
+
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal(*resp.Result, &result))
+	require.Len(t, result.Content, 1)
+	return result.Content[0].Text
+}
+
+func TestTransport(t *testing.T) {
+	assert := assert.New(t)
+
+	transport := newHelperTransport(t)
+	client := &http.Client{Transport: transport}
+
+	initParams := map[string]any{
+		"protocolVersion": "2025-06-18",
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": t.Name(), "version": "1.0"},
+	}
+	initResp, httpResp := doTransportRequest(t, client, mcpconst.Initialize, initParams)
+	require.NotNil(t, initResp)
+	require.Nil(t, initResp.Error)
+	sessionID := httpResp.Header.Get(mcpconst.MCP_SESSION_ID_HEADER)
+	assert.NotEmpty(sessionID)
+
+	// a second initialize is answered from the cache, with the same session
+	_, httpResp = doTransportRequest(t, client, mcpconst.Initialize, initParams)
+	assert.Equal(sessionID, httpResp.Header.Get(mcpconst.MCP_SESSION_ID_HEADER))
+
+	_, httpResp = doTransportRequest(t, client, mcpconst.NotificationsInitialized, nil)
+	assert.Equal(http.StatusAccepted, httpResp.StatusCode)
+
+	pingResp, _ := doTransportRequest(t, client, mcpconst.Ping, nil)
+	require.NotNil(t, pingResp)
+	assert.Nil(pingResp.Error)
+
+	// concurrent calls each get their own answer back
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(fmt.Sprintf("%d", i+100), doAdd(t, client, i, 100))
+		}()
+	}
+	wg.Wait()
+
+	// a caller giving up on a slow tool gets its error straight away, and the child,
+	// told to cancel, carries on serving everyone else
+	slowCtx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	slowParams := map[string]any{"name": examplemcp.TOOL_COUNT_STEPS, "arguments": map[string]any{examplemcp.PARAM_STEPS: 50}}
+	slowReq, err := jsonrpc.NewJSONRPCRequest(slowCtx, transport.URL(), mcpconst.ToolsCall, slowParams, nil, http.NewRequestWithContext)
+	require.NoError(t, err)
+	_, _, err = jsonrpc.DoRequest(slowCtx, client, slowReq)
+	assert.ErrorContains(err, context.DeadlineExceeded.Error())
+	transport.mu.Lock()
+	assert.Empty(transport.pending)
+	transport.mu.Unlock()
+	assert.Equal("5", doAdd(t, client, 2, 3))
+
+	// kill the child, the next call should get a fresh, re-initialized one
+	transport.mu.Lock()
+	crashed := transport.child
+	transport.mu.Unlock()
+	require.NotNil(t, crashed)
+	require.NoError(t, crashed.cmd.Process.Kill())
+	<-crashed.done
+
+	assert.Equal("3", doAdd(t, client, 1, 2))
+
+	transport.mu.Lock()
+	assert.NotSame(crashed, transport.child)
+	transport.mu.Unlock()
+}
+
+func TestTransportClosed(t *testing.T) {
+	transport := newHelperTransport(t)
+	require.NoError(t, transport.Close())
+
+	req, err := jsonrpc.NewJSONRPCRequest(t.Context(), transport.URL(), mcpconst.Ping, nil, nil, http.NewRequestWithContext)
+	require.NoError(t, err)
+
+	_, _, err = jsonrpc.DoRequest(t.Context(), &http.Client{Transport: transport}, req)
+	assert.ErrorContains(t, err, errTransportClosed.Error())
+}
+
+func TestSameClient(t *testing.T) {
+	initialize := func(params string) []byte {
+		return []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":` + params + `}`)
+	}
+	first := initialize(`{"protocolVersion":"2025-06-18","capabilities":{"sampling":{}},"clientInfo":{"name":"a"}}`)
+
+	assert.True(t, sameClient(first, initialize(`{ "capabilities": {"sampling": {}}, "clientInfo": {"name": "a"} }`)))
+	assert.False(t, sameClient(first, initialize(`{"capabilities":{},"clientInfo":{"name":"a"}}`)))
+	assert.False(t, sameClient(first, initialize(`{"capabilities":{"sampling":{}},"clientInfo":{"name":"b"}}`)))
+}
@@ -0,0 +1,94 @@
+package toolproto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	_ "grpc2mcp/pb" // registers mcp.proto, which generated files import
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	_ "google.golang.org/protobuf/types/known/structpb"
+)
+
+// Descriptor builds the file into a descriptor that dynamicpb can make messages from.
+func (f *File) Descriptor() (protoreflect.FileDescriptor, error) {
+	return protodesc.NewFile(f.Proto, protoregistry.GlobalFiles)
+}
+
+// ToArguments turns a request message into tool arguments. We walk the message rather
+// than using protojson as that quotes 64 bit integers, which JSON schema wouldn't.
+func ToArguments(msg protoreflect.Message) map[string]any {
+	return messageValue(msg).(map[string]any)
+}
+
+func messageValue(msg protoreflect.Message) any {
+
+	if msg.Descriptor().FullName().Parent() == "google.protobuf" {
+		var v any
+		b, err := protojson.Marshal(msg.Interface())
+		if err == nil {
+			err = json.Unmarshal(b, &v)
+		}
+		if err != nil {
+			return nil
+		}
+		return v
+	}
+
+	fields := map[string]any{}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fields[fd.JSONName()] = fieldValue(fd, v)
+		return true
+	})
+	return fields
+}
+
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.IsMap():
+		m := map[string]any{}
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			m[k.String()] = singularValue(fd.MapValue(), mv)
+			return true
+		})
+		return m
+	case fd.IsList():
+		list := v.List()
+		values := make([]any, list.Len())
+		for i := range values {
+			values[i] = singularValue(fd, list.Get(i))
+		}
+		return values
+	default:
+		return singularValue(fd, v)
+	}
+}
+
+func singularValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(v.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageValue(v.Message())
+	}
+	return nil
+}
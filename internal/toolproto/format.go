@@ -0,0 +1,119 @@
+package toolproto
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Format prints the file as .proto source.
+func (f *File) Format() string {
+
+	var b strings.Builder
+	fdp := f.Proto
+	pkg := fdp.GetPackage()
+
+	b.WriteString("// Code generated by grpc2mcp gen-proto. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", pkg)
+	for _, dep := range fdp.GetDependency() {
+		fmt.Fprintf(&b, "import %q;\n", dep)
+	}
+	if goPackage := fdp.GetOptions().GetGoPackage(); goPackage != "" {
+		fmt.Fprintf(&b, "\noption go_package = %q;\n", goPackage)
+	}
+
+	for _, service := range fdp.GetService() {
+		serviceName := pkg + "." + service.GetName()
+		b.WriteString("\n")
+		f.writeComment(&b, "", serviceName)
+		fmt.Fprintf(&b, "service %s {\n", service.GetName())
+		for _, method := range service.GetMethod() {
+			f.writeComment(&b, "    ", serviceName+"."+method.GetName())
+			fmt.Fprintf(&b, "    rpc %s(%s) returns (%s);\n", method.GetName(),
+				relativeType(pkg, method.GetInputType()), relativeType(pkg, method.GetOutputType()))
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, msg := range fdp.GetMessageType() {
+		b.WriteString("\n")
+		f.writeMessage(&b, "", pkg, pkg+"."+msg.GetName(), msg)
+	}
+	return b.String()
+}
+
+func (f *File) writeMessage(b *strings.Builder, indent string, pkg string, fullName string, msg *descriptorpb.DescriptorProto) {
+
+	f.writeComment(b, indent, fullName)
+	fmt.Fprintf(b, "%smessage %s {\n", indent, msg.GetName())
+	for _, field := range msg.GetField() {
+		f.writeComment(b, indent+"    ", fullName+"."+field.GetName())
+		label := ""
+		switch {
+		case field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+			label = "repeated "
+		case field.GetProto3Optional():
+			label = "optional "
+		}
+		options := ""
+		if field.GetJsonName() != defaultJSONName(field.GetName()) {
+			options = fmt.Sprintf(" [json_name = %q]", field.GetJsonName())
+		}
+		fmt.Fprintf(b, "%s    %s%s %s = %d%s;\n", indent, label, fieldType(pkg, field), field.GetName(), field.GetNumber(), options)
+	}
+	for _, nested := range msg.GetNestedType() {
+		b.WriteString("\n")
+		f.writeMessage(b, indent+"    ", pkg, fullName+"."+nested.GetName(), nested)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func (f *File) writeComment(b *strings.Builder, indent string, fullName string) {
+	comment := strings.TrimSpace(f.comments[fullName])
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimRight(line, " \t\r"))
+	}
+}
+
+func fieldType(pkg string, field *descriptorpb.FieldDescriptorProto) string {
+	switch field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return "string"
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
+		return "int64"
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return "double"
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "bool"
+	}
+	return relativeType(pkg, field.GetTypeName())
+}
+
+// relativeType trims a fully qualified type name down to what reads naturally in pkg.
+func relativeType(pkg string, typeName string) string {
+	typeName = strings.TrimPrefix(typeName, ".")
+	return strings.TrimPrefix(typeName, pkg+".")
+}
+
+// defaultJSONName is the json name protoc gives a field when there's no json_name option.
+func defaultJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,484 @@
+// Package toolproto turns the JSON schemas of an MCP server's tools into a proto file
+// with one strongly typed RPC per tool.
+package toolproto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	structProto = "google/protobuf/struct.proto"
+	mcpProto    = "mcp.proto"
+
+	structType         = ".google.protobuf.Struct"
+	valueType          = ".google.protobuf.Value"
+	listValueType      = ".google.protobuf.ListValue"
+	callToolResultType = ".mcp.CallToolResult"
+
+	// field numbers run up to maxFieldNumber, less the range protobuf keeps for itself
+	maxFieldNumber           = 1<<29 - 1
+	firstReservedFieldNumber = 19000
+	lastReservedFieldNumber  = 19999
+)
+
+// Tool is the part of an MCP tool that we need to type it. The schemas are kept raw
+// since the proto JSONSchema message only carries a sliver of what they say.
+type Tool struct {
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	InputSchema  json.RawMessage `json:"inputSchema"`
+	OutputSchema json.RawMessage `json:"outputSchema,omitempty"`
+}
+
+// Schema is the subset of JSON schema that maps onto proto types.
+type Schema struct {
+	Type        any                `json:"type"`
+	Description string             `json:"description"`
+	Properties  map[string]*Schema `json:"properties"`
+	Items       *Schema            `json:"items"`
+	Enum        []any              `json:"enum"`
+
+	// order holds the property names in the order the schema declares them
+	order []string
+}
+
+// UnmarshalJSON decodes a schema, noting the order of its properties as well, which a
+// map loses.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type plain Schema
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	var raw struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	order, err := objectKeys(raw.Properties)
+	if err != nil {
+		return err
+	}
+	s.order = order
+	return nil
+}
+
+// objectKeys returns the keys of a JSON object in order, nil if it isn't an object.
+func objectKeys(data json.RawMessage) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil
+	}
+	var keys []string
+	seen := map[string]bool{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		if key := tok.(string); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// properties returns the property names in declaration order, followed by any that
+// weren't declared in JSON, sorted.
+func (s *Schema) properties() []string {
+	properties := make([]string, 0, len(s.Properties))
+	declared := map[string]bool{}
+	for _, property := range s.order {
+		if _, ok := s.Properties[property]; ok && !declared[property] {
+			declared[property] = true
+			properties = append(properties, property)
+		}
+	}
+	var rest []string
+	for property := range s.Properties {
+		if !declared[property] {
+			rest = append(rest, property)
+		}
+	}
+	sort.Strings(rest)
+	return append(properties, rest...)
+}
+
+// types returns the schema's types other than null, which only says a field is optional.
+func (s *Schema) types() []string {
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		types = append(types, t)
+	case []any:
+		for _, v := range t {
+			if str, ok := v.(string); ok {
+				types = append(types, str)
+			}
+		}
+	}
+	nonNull := types[:0]
+	for _, t := range types {
+		if t != "null" {
+			nonNull = append(nonNull, t)
+		}
+	}
+	return nonNull
+}
+
+type Options struct {
+	// Package is the proto package of the generated file, eg "mcp.tools"
+	Package string
+	// Service is the name of the generated service, eg "Tools"
+	Service string
+	// GoPackage sets the go_package option when it isn't empty
+	GoPackage string
+	// FieldNumbers pins the numbers of fields, keyed by message and JSON property path,
+	// eg "GetIssueRequest.labels" or "GetIssueRequest.filter.state"
+	FieldNumbers map[string]int32
+}
+
+// File is a generated proto file along with what's needed to serve and print it.
+type File struct {
+	Proto *descriptorpb.FileDescriptorProto
+	// ToolNames maps each RPC name to the tool it calls
+	ToolNames map[string]string
+	// comments holds descriptions keyed by the full name of the element they describe
+	comments map[string]string
+	// pinned is opts.FieldNumbers, usedPins the keys of it that matched a field
+	pinned   map[string]int32
+	usedPins map[string]bool
+}
+
+// NewFile generates a proto file with a service holding one RPC per tool. Requests are
+// messages built from each tool's inputSchema, as are responses for tools that declare an
+// outputSchema. Other tools return the generic mcp.CallToolResult.
+//
+// Fields are numbered in the order the schema declares its properties, skipping numbers
+// pinned by opts.FieldNumbers. A tool that gains a property at the end keeps its numbers,
+// but one declared in between, or a server that sorts its properties (as mcp-go does),
+// renumbers the fields after it, which breaks clients of the previous file. Pin the
+// numbers of fields that clients already depend on to keep them.
+func NewFile(opts Options, tools []Tool) (*File, error) {
+
+	f := &File{
+		Proto: &descriptorpb.FileDescriptorProto{
+			Name:       proto.String(strings.ReplaceAll(opts.Package, ".", "/") + "/" + toSnake(opts.Service) + ".proto"),
+			Package:    proto.String(opts.Package),
+			Dependency: []string{structProto},
+			Syntax:     proto.String("proto3"),
+		},
+		ToolNames: map[string]string{},
+		comments:  map[string]string{},
+		pinned:    opts.FieldNumbers,
+		usedPins:  map[string]bool{},
+	}
+	if opts.GoPackage != "" {
+		f.Proto.Options = &descriptorpb.FileOptions{GoPackage: proto.String(opts.GoPackage)}
+	}
+
+	service := &descriptorpb.ServiceDescriptorProto{Name: proto.String(opts.Service)}
+	serviceName := opts.Package + "." + opts.Service
+	f.comments[serviceName] = "Generated by grpc2mcp gen-proto, one RPC per MCP tool."
+
+	sorted := append([]Tool(nil), tools...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	rpcNames := map[string]bool{}
+	for _, tool := range sorted {
+
+		rpcName := uniqueName(toCamel(tool.Name), rpcNames)
+		f.ToolNames[rpcName] = tool.Name
+
+		inputSchema, err := parseSchema(tool.InputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("inputSchema of tool %s: %w", tool.Name, err)
+		}
+		request, err := f.newMessage(opts.Package, rpcName+"Request", rpcName+"Request", inputSchema)
+		if err != nil {
+			return nil, err
+		}
+		f.Proto.MessageType = append(f.Proto.MessageType, request)
+
+		outputType := callToolResultType
+		if len(tool.OutputSchema) > 0 && string(tool.OutputSchema) != "null" {
+			outputSchema, err := parseSchema(tool.OutputSchema)
+			if err != nil {
+				return nil, fmt.Errorf("outputSchema of tool %s: %w", tool.Name, err)
+			}
+			response, err := f.newMessage(opts.Package, rpcName+"Response", rpcName+"Response", outputSchema)
+			if err != nil {
+				return nil, err
+			}
+			f.Proto.MessageType = append(f.Proto.MessageType, response)
+			outputType = "." + opts.Package + "." + response.GetName()
+		}
+
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(rpcName),
+			InputType:  proto.String("." + opts.Package + "." + request.GetName()),
+			OutputType: proto.String(outputType),
+		})
+		f.comments[serviceName+"."+rpcName] = tool.Description
+	}
+
+	for key := range opts.FieldNumbers {
+		if !f.usedPins[key] {
+			return nil, fmt.Errorf("field number pinned for %s, which no tool has", key)
+		}
+	}
+	if slices.ContainsFunc(service.Method, func(m *descriptorpb.MethodDescriptorProto) bool {
+		return m.GetOutputType() == callToolResultType
+	}) {
+		f.Proto.Dependency = append(f.Proto.Dependency, mcpProto)
+	}
+
+	f.Proto.Service = []*descriptorpb.ServiceDescriptorProto{service}
+	return f, nil
+}
+
+func parseSchema(raw json.RawMessage) (*Schema, error) {
+	schema := &Schema{}
+	if len(raw) == 0 {
+		return schema, nil
+	}
+	if err := json.Unmarshal(raw, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// newMessage builds the message for an object schema, nesting a message for each
+// property that is itself an object with properties. path is what opts.FieldNumbers
+// keys the message's fields by, less the property.
+func (f *File) newMessage(scope string, name string, path string, schema *Schema) (*descriptorpb.DescriptorProto, error) {
+
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	fullName := scope + "." + name
+	f.comments[fullName] = schema.Description
+
+	properties := schema.properties()
+	numbers, err := f.numberFields(path, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldNames := map[string]bool{}
+	nestedNames := map[string]bool{}
+	for i, property := range properties {
+		propertySchema := schema.Properties[property]
+		if propertySchema == nil {
+			propertySchema = &Schema{}
+		}
+		fieldName := uniqueName(toIdentifier(property), fieldNames)
+		field := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(fieldName),
+			Number:   proto.Int32(numbers[i]),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			JsonName: proto.String(property),
+		}
+
+		fieldSchema := propertySchema
+		if isArray(propertySchema) && propertySchema.Items != nil && !isArray(propertySchema.Items) {
+			field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			fieldSchema = propertySchema.Items
+		}
+		if err := f.setFieldType(field, fieldSchema, msg, fullName, path+"."+property, nestedNames); err != nil {
+			return nil, err
+		}
+
+		// singular scalars get explicit presence, so zero values still go to the server
+		if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL &&
+			field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			field.Proto3Optional = proto.Bool(true)
+			field.OneofIndex = proto.Int32(int32(len(msg.OneofDecl)))
+			msg.OneofDecl = append(msg.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + fieldName)})
+		}
+
+		msg.Field = append(msg.Field, field)
+		f.comments[fullName+"."+fieldName] = describe(propertySchema)
+	}
+	return msg, nil
+}
+
+// numberFields numbers the properties of the message at path. Pinned ones get their
+// pinned number, the rest the lowest numbers left over, in order.
+func (f *File) numberFields(path string, properties []string) ([]int32, error) {
+	numbers := make([]int32, len(properties))
+	taken := map[int32]string{}
+	for i, property := range properties {
+		key := path + "." + property
+		number, ok := f.pinned[key]
+		if !ok {
+			continue
+		}
+		f.usedPins[key] = true
+		if number < 1 || number > maxFieldNumber || reservedFieldNumber(number) {
+			return nil, fmt.Errorf("field number %d pinned for %s isn't a valid field number", number, key)
+		}
+		if other := taken[number]; other != "" {
+			return nil, fmt.Errorf("field number %d pinned for both %s and %s", number, other, key)
+		}
+		taken[number] = key
+		numbers[i] = number
+	}
+
+	next := int32(1)
+	for i, property := range properties {
+		if numbers[i] != 0 {
+			continue
+		}
+		for taken[next] != "" || reservedFieldNumber(next) {
+			next++
+		}
+		numbers[i] = next
+		taken[next] = path + "." + property
+	}
+	return numbers, nil
+}
+
+func reservedFieldNumber(number int32) bool {
+	return number >= firstReservedFieldNumber && number <= lastReservedFieldNumber
+}
+
+func (f *File) setFieldType(field *descriptorpb.FieldDescriptorProto, schema *Schema,
+	parent *descriptorpb.DescriptorProto, parentName string, path string, nestedNames map[string]bool) error {
+
+	types := schema.types()
+	if len(types) != 1 {
+		// untyped, or any of several types
+		setMessageType(field, valueType)
+		return nil
+	}
+
+	switch types[0] {
+	case "string":
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	case "integer":
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	case "number":
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+	case "boolean":
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
+	case "array":
+		setMessageType(field, listValueType)
+	case "object":
+		if len(schema.Properties) == 0 {
+			setMessageType(field, structType)
+			return nil
+		}
+		nestedName := uniqueName(toCamel(field.GetName()), nestedNames)
+		nested, err := f.newMessage(parentName, nestedName, path, schema)
+		if err != nil {
+			return err
+		}
+		parent.NestedType = append(parent.NestedType, nested)
+		setMessageType(field, "."+parentName+"."+nestedName)
+	default:
+		setMessageType(field, valueType)
+	}
+	return nil
+}
+
+func setMessageType(field *descriptorpb.FieldDescriptorProto, typeName string) {
+	field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	field.TypeName = proto.String(typeName)
+}
+
+func isArray(schema *Schema) bool {
+	types := schema.types()
+	return len(types) == 1 && types[0] == "array"
+}
+
+// describe is a field's description, with any enumerated values tacked on since proto
+// enums can't hold arbitrary JSON values.
+func describe(schema *Schema) string {
+	description := schema.Description
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, v := range schema.Enum {
+			b, _ := json.Marshal(v)
+			values[i] = string(b)
+		}
+		if description != "" {
+			description += "\n"
+		}
+		description += "One of: " + strings.Join(values, ", ")
+	}
+	return description
+}
+
+// toIdentifier makes a JSON property name into a valid proto identifier.
+func toIdentifier(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	id := b.String()
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "_" + id
+	}
+	return id
+}
+
+// toCamel makes names like "get_me" or "greetResource" into "GetMe" and "GreetResource".
+func toCamel(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range toIdentifier(name) {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 || unicode.IsDigit(rune(b.String()[0])) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+func toSnake(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
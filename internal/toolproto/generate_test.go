@@ -0,0 +1,161 @@
+package toolproto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var testTools = []Tool{
+	{
+		Name:        "get_issue",
+		Description: "Fetch an issue",
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"issue-number": {"type": "integer", "description": "The issue to get"},
+				"labels": {"type": "array", "items": {"type": "string"}},
+				"filter": {"type": "object", "properties": {"state": {"type": "string", "enum": ["open", "closed"]}}},
+				"extra": {"type": "object"},
+				"anything": {}
+			},
+			"required": ["issue-number"]
+		}`),
+		OutputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {"title": {"type": ["string", "null"]}, "score": {"type": "number"}}
+		}`),
+	},
+	{
+		Name:        "ping",
+		InputSchema: json.RawMessage(`{"type": "object"}`),
+	},
+}
+
+const expectedProto = `// Code generated by grpc2mcp gen-proto. DO NOT EDIT.
+
+syntax = "proto3";
+
+package mcp.tools;
+
+import "google/protobuf/struct.proto";
+import "mcp.proto";
+
+option go_package = "example.com/tools";
+
+// Generated by grpc2mcp gen-proto, one RPC per MCP tool.
+service Tools {
+    // Fetch an issue
+    rpc GetIssue(GetIssueRequest) returns (GetIssueResponse);
+    rpc Ping(PingRequest) returns (mcp.CallToolResult);
+}
+
+message GetIssueRequest {
+    // The issue to get
+    optional int64 issue_number = 1 [json_name = "issue-number"];
+    repeated string labels = 2;
+    GetIssueRequest.Filter filter = 3;
+    google.protobuf.Struct extra = 4;
+    google.protobuf.Value anything = 5;
+
+    message Filter {
+        // One of: "open", "closed"
+        optional string state = 1;
+    }
+}
+
+message GetIssueResponse {
+    optional string title = 1;
+    optional double score = 2;
+}
+
+message PingRequest {
+}
+`
+
+func TestNewFile(t *testing.T) {
+
+	assert := assert.New(t)
+
+	f, err := NewFile(Options{Package: "mcp.tools", Service: "Tools", GoPackage: "example.com/tools"}, testTools)
+	require.NoError(t, err)
+	assert.Equal(map[string]string{"GetIssue": "get_issue", "Ping": "ping"}, f.ToolNames)
+	assert.Equal(expectedProto, f.Format())
+
+	fd, err := f.Descriptor()
+	require.NoError(t, err)
+	assert.Equal("mcp/tools/tools.proto", fd.Path())
+
+	method := fd.Services().ByName("Tools").Methods().ByName("GetIssue")
+	require.NotNil(t, method)
+
+	in := dynamicpb.NewMessage(method.Input())
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"issue-number": 0,
+		"labels": ["bug"],
+		"filter": {"state": "open"},
+		"extra": {"k": "v"},
+		"anything": [1, "two"]
+	}`), in))
+
+	args, err := json.Marshal(ToArguments(in))
+	require.NoError(t, err)
+	// unlike protojson, 64 bit integers stay numbers and zeros with presence are kept
+	assert.JSONEq(`{
+		"issue-number": 0,
+		"labels": ["bug"],
+		"filter": {"state": "open"},
+		"extra": {"k": "v"},
+		"anything": [1, "two"]
+	}`, string(args))
+}
+
+func TestNewFile_FieldNumbers(t *testing.T) {
+
+	assert := assert.New(t)
+
+	opts := Options{Package: "mcp.tools", Service: "Tools", FieldNumbers: map[string]int32{
+		"GetIssueRequest.labels":       1,
+		"GetIssueRequest.filter.state": 7,
+	}}
+	f, err := NewFile(opts, testTools)
+	require.NoError(t, err)
+	fd, err := f.Descriptor()
+	require.NoError(t, err)
+
+	request := fd.Messages().ByName("GetIssueRequest")
+	numbers := map[string]int32{}
+	for i := range request.Fields().Len() {
+		field := request.Fields().Get(i)
+		numbers[string(field.Name())] = int32(field.Number())
+	}
+	// the unpinned fields take the numbers left over, in the order they're declared
+	assert.Equal(map[string]int32{"issue_number": 2, "labels": 1, "filter": 3, "extra": 4, "anything": 5}, numbers)
+	assert.EqualValues(7, request.Messages().ByName("Filter").Fields().ByName("state").Number())
+
+	opts.FieldNumbers = map[string]int32{"GetIssueRequest.labels": 1, "GetIssueRequest.extra": 1}
+	_, err = NewFile(opts, testTools)
+	assert.ErrorContains(err, "pinned for both")
+
+	opts.FieldNumbers = map[string]int32{"GetIssueRequest.label": 1}
+	_, err = NewFile(opts, testTools)
+	assert.ErrorContains(err, "which no tool has")
+
+	opts.FieldNumbers = map[string]int32{"GetIssueRequest.labels": 19000}
+	_, err = NewFile(opts, testTools)
+	assert.ErrorContains(err, "isn't a valid field number")
+}
+
+// mcp.proto is only imported when some tool returns a plain mcp.CallToolResult
+func TestNewFile_ImportsMCPProtoWhenUsed(t *testing.T) {
+
+	f, err := NewFile(Options{Package: "mcp.tools", Service: "Tools"}, testTools[:1])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"google/protobuf/struct.proto"}, f.Proto.GetDependency())
+	_, err = f.Descriptor()
+	require.NoError(t, err)
+}
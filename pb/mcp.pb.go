@@ -71,6 +71,125 @@ func (Role) EnumDescriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{0}
 }
 
+type ElicitAction int32
+
+const (
+	ElicitAction_ELICIT_ACTION_UNSPECIFIED ElicitAction = 0
+	ElicitAction_ACCEPT                    ElicitAction = 1
+	ElicitAction_DECLINE                   ElicitAction = 2
+	ElicitAction_CANCEL                    ElicitAction = 3
+)
+
+// Enum value maps for ElicitAction.
+var (
+	ElicitAction_name = map[int32]string{
+		0: "ELICIT_ACTION_UNSPECIFIED",
+		1: "ACCEPT",
+		2: "DECLINE",
+		3: "CANCEL",
+	}
+	ElicitAction_value = map[string]int32{
+		"ELICIT_ACTION_UNSPECIFIED": 0,
+		"ACCEPT":                    1,
+		"DECLINE":                   2,
+		"CANCEL":                    3,
+	}
+)
+
+func (x ElicitAction) Enum() *ElicitAction {
+	p := new(ElicitAction)
+	*p = x
+	return p
+}
+
+func (x ElicitAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ElicitAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_mcp_proto_enumTypes[1].Descriptor()
+}
+
+func (ElicitAction) Type() protoreflect.EnumType {
+	return &file_mcp_proto_enumTypes[1]
+}
+
+func (x ElicitAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ElicitAction.Descriptor instead.
+func (ElicitAction) EnumDescriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{1}
+}
+
+type LoggingLevel int32
+
+const (
+	LoggingLevel_LOGGING_LEVEL_UNSPECIFIED LoggingLevel = 0
+	LoggingLevel_DEBUG                     LoggingLevel = 1
+	LoggingLevel_INFO                      LoggingLevel = 2
+	LoggingLevel_NOTICE                    LoggingLevel = 3
+	LoggingLevel_WARNING                   LoggingLevel = 4
+	LoggingLevel_ERROR                     LoggingLevel = 5
+	LoggingLevel_CRITICAL                  LoggingLevel = 6
+	LoggingLevel_ALERT                     LoggingLevel = 7
+	LoggingLevel_EMERGENCY                 LoggingLevel = 8
+)
+
+// Enum value maps for LoggingLevel.
+var (
+	LoggingLevel_name = map[int32]string{
+		0: "LOGGING_LEVEL_UNSPECIFIED",
+		1: "DEBUG",
+		2: "INFO",
+		3: "NOTICE",
+		4: "WARNING",
+		5: "ERROR",
+		6: "CRITICAL",
+		7: "ALERT",
+		8: "EMERGENCY",
+	}
+	LoggingLevel_value = map[string]int32{
+		"LOGGING_LEVEL_UNSPECIFIED": 0,
+		"DEBUG":                     1,
+		"INFO":                      2,
+		"NOTICE":                    3,
+		"WARNING":                   4,
+		"ERROR":                     5,
+		"CRITICAL":                  6,
+		"ALERT":                     7,
+		"EMERGENCY":                 8,
+	}
+)
+
+func (x LoggingLevel) Enum() *LoggingLevel {
+	p := new(LoggingLevel)
+	*p = x
+	return p
+}
+
+func (x LoggingLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LoggingLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_mcp_proto_enumTypes[2].Descriptor()
+}
+
+func (LoggingLevel) Type() protoreflect.EnumType {
+	return &file_mcp_proto_enumTypes[2]
+}
+
+func (x LoggingLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LoggingLevel.Descriptor instead.
+func (LoggingLevel) EnumDescriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{2}
+}
+
 type ListResourcesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
@@ -295,29 +414,30 @@ func (x *ListResourceTemplatesResult) GetXMeta() *structpb.Struct {
 	return nil
 }
 
-type InitializeRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ProtocolVersion string                 `protobuf:"bytes,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
-	Capabilities    *ClientCapabilities    `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
-	ClientInfo      *Implementation        `protobuf:"bytes,3,opt,name=clientInfo,proto3" json:"clientInfo,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// ListAllRequest has the proxy page through a list itself, streaming back each item.
+// maxPages stops it early, it can't go past the proxy's own limit.
+type ListAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxPages      *int32                 `protobuf:"varint,1,opt,name=maxPages,proto3,oneof" json:"maxPages,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InitializeRequest) Reset() {
-	*x = InitializeRequest{}
+func (x *ListAllRequest) Reset() {
+	*x = ListAllRequest{}
 	mi := &file_mcp_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitializeRequest) String() string {
+func (x *ListAllRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitializeRequest) ProtoMessage() {}
+func (*ListAllRequest) ProtoMessage() {}
 
-func (x *InitializeRequest) ProtoReflect() protoreflect.Message {
+func (x *ListAllRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -329,56 +449,47 @@ func (x *InitializeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitializeRequest.ProtoReflect.Descriptor instead.
-func (*InitializeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListAllRequest.ProtoReflect.Descriptor instead.
+func (*ListAllRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *InitializeRequest) GetProtocolVersion() string {
-	if x != nil {
-		return x.ProtocolVersion
-	}
-	return ""
-}
-
-func (x *InitializeRequest) GetCapabilities() *ClientCapabilities {
-	if x != nil {
-		return x.Capabilities
+func (x *ListAllRequest) GetMaxPages() int32 {
+	if x != nil && x.MaxPages != nil {
+		return *x.MaxPages
 	}
-	return nil
+	return 0
 }
 
-func (x *InitializeRequest) GetClientInfo() *Implementation {
+func (x *ListAllRequest) GetXMeta() *structpb.Struct {
 	if x != nil {
-		return x.ClientInfo
+		return x.XMeta
 	}
 	return nil
 }
 
-type InitializeResult struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ProtocolVersion string                 `protobuf:"bytes,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
-	Capabilities    *ServerCapabilities    `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
-	ServerInfo      *Implementation        `protobuf:"bytes,3,opt,name=serverInfo,proto3" json:"serverInfo,omitempty"`
-	Instructions    *string                `protobuf:"bytes,4,opt,name=instructions,proto3,oneof" json:"instructions,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ReadResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InitializeResult) Reset() {
-	*x = InitializeResult{}
+func (x *ReadResourceRequest) Reset() {
+	*x = ReadResourceRequest{}
 	mi := &file_mcp_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitializeResult) String() string {
+func (x *ReadResourceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitializeResult) ProtoMessage() {}
+func (*ReadResourceRequest) ProtoMessage() {}
 
-func (x *InitializeResult) ProtoReflect() protoreflect.Message {
+func (x *ReadResourceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -390,61 +501,47 @@ func (x *InitializeResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitializeResult.ProtoReflect.Descriptor instead.
-func (*InitializeResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadResourceRequest.ProtoReflect.Descriptor instead.
+func (*ReadResourceRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *InitializeResult) GetProtocolVersion() string {
+func (x *ReadResourceRequest) GetUri() string {
 	if x != nil {
-		return x.ProtocolVersion
+		return x.Uri
 	}
 	return ""
 }
 
-func (x *InitializeResult) GetCapabilities() *ServerCapabilities {
-	if x != nil {
-		return x.Capabilities
-	}
-	return nil
-}
-
-func (x *InitializeResult) GetServerInfo() *Implementation {
+func (x *ReadResourceRequest) GetXMeta() *structpb.Struct {
 	if x != nil {
-		return x.ServerInfo
+		return x.XMeta
 	}
 	return nil
 }
 
-func (x *InitializeResult) GetInstructions() string {
-	if x != nil && x.Instructions != nil {
-		return *x.Instructions
-	}
-	return ""
-}
-
-type ListToolsRequest struct {
+type ReadResourceResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	Contents      []*ResourceContents    `protobuf:"bytes,1,rep,name=contents,proto3" json:"contents,omitempty"`
 	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListToolsRequest) Reset() {
-	*x = ListToolsRequest{}
+func (x *ReadResourceResult) Reset() {
+	*x = ReadResourceResult{}
 	mi := &file_mcp_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListToolsRequest) String() string {
+func (x *ReadResourceResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListToolsRequest) ProtoMessage() {}
+func (*ReadResourceResult) ProtoMessage() {}
 
-func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
+func (x *ReadResourceResult) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -456,48 +553,47 @@ func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListToolsRequest.ProtoReflect.Descriptor instead.
-func (*ListToolsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadResourceResult.ProtoReflect.Descriptor instead.
+func (*ReadResourceResult) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListToolsRequest) GetCursor() string {
-	if x != nil && x.Cursor != nil {
-		return *x.Cursor
+func (x *ReadResourceResult) GetContents() []*ResourceContents {
+	if x != nil {
+		return x.Contents
 	}
-	return ""
+	return nil
 }
 
-func (x *ListToolsRequest) GetXMeta() *structpb.Struct {
+func (x *ReadResourceResult) GetXMeta() *structpb.Struct {
 	if x != nil {
 		return x.XMeta
 	}
 	return nil
 }
 
-type ListToolsResult struct {
+type SubscribeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tools         []*Tool                `protobuf:"bytes,1,rep,name=tools,proto3" json:"tools,omitempty"`
-	NextCursor    *string                `protobuf:"bytes,2,opt,name=nextCursor,proto3,oneof" json:"nextCursor,omitempty"`
-	XMeta         *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListToolsResult) Reset() {
-	*x = ListToolsResult{}
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
 	mi := &file_mcp_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListToolsResult) String() string {
+func (x *SubscribeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListToolsResult) ProtoMessage() {}
+func (*SubscribeRequest) ProtoMessage() {}
 
-func (x *ListToolsResult) ProtoReflect() protoreflect.Message {
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -509,55 +605,47 @@ func (x *ListToolsResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListToolsResult.ProtoReflect.Descriptor instead.
-func (*ListToolsResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListToolsResult) GetTools() []*Tool {
+func (x *SubscribeRequest) GetUri() string {
 	if x != nil {
-		return x.Tools
-	}
-	return nil
-}
-
-func (x *ListToolsResult) GetNextCursor() string {
-	if x != nil && x.NextCursor != nil {
-		return *x.NextCursor
+		return x.Uri
 	}
 	return ""
 }
 
-func (x *ListToolsResult) GetXMeta() *structpb.Struct {
+func (x *SubscribeRequest) GetXMeta() *structpb.Struct {
 	if x != nil {
 		return x.XMeta
 	}
 	return nil
 }
 
-type CallToolRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	Name          string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Arguments     map[string]*structpb.Value `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	XMeta         *structpb.Struct           `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+type ResourceUpdatedNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CallToolRequest) Reset() {
-	*x = CallToolRequest{}
+func (x *ResourceUpdatedNotification) Reset() {
+	*x = ResourceUpdatedNotification{}
 	mi := &file_mcp_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CallToolRequest) String() string {
+func (x *ResourceUpdatedNotification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CallToolRequest) ProtoMessage() {}
+func (*ResourceUpdatedNotification) ProtoMessage() {}
 
-func (x *CallToolRequest) ProtoReflect() protoreflect.Message {
+func (x *ResourceUpdatedNotification) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -569,55 +657,51 @@ func (x *CallToolRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CallToolRequest.ProtoReflect.Descriptor instead.
-func (*CallToolRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResourceUpdatedNotification.ProtoReflect.Descriptor instead.
+func (*ResourceUpdatedNotification) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CallToolRequest) GetName() string {
+func (x *ResourceUpdatedNotification) GetUri() string {
 	if x != nil {
-		return x.Name
+		return x.Uri
 	}
 	return ""
 }
 
-func (x *CallToolRequest) GetArguments() map[string]*structpb.Value {
-	if x != nil {
-		return x.Arguments
-	}
-	return nil
-}
-
-func (x *CallToolRequest) GetXMeta() *structpb.Struct {
+func (x *ResourceUpdatedNotification) GetXMeta() *structpb.Struct {
 	if x != nil {
 		return x.XMeta
 	}
 	return nil
 }
 
-type CallToolResult struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Content           []*ContentBlock        `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
-	StructuredContent *structpb.Struct       `protobuf:"bytes,2,opt,name=structuredContent,proto3,oneof" json:"structuredContent,omitempty"`
-	IsError           *bool                  `protobuf:"varint,3,opt,name=isError,proto3,oneof" json:"isError,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type InitializeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolVersion string                 `protobuf:"bytes,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Capabilities    *ClientCapabilities    `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	ClientInfo      *Implementation        `protobuf:"bytes,3,opt,name=clientInfo,proto3" json:"clientInfo,omitempty"`
+	// roots aren't sent on initialize, the proxy keeps them to answer the server's
+	// roots/list with, as if set by SetRoots
+	Roots         []*Root `protobuf:"bytes,4,rep,name=roots,proto3" json:"roots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CallToolResult) Reset() {
-	*x = CallToolResult{}
+func (x *InitializeRequest) Reset() {
+	*x = InitializeRequest{}
 	mi := &file_mcp_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CallToolResult) String() string {
+func (x *InitializeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CallToolResult) ProtoMessage() {}
+func (*InitializeRequest) ProtoMessage() {}
 
-func (x *CallToolResult) ProtoReflect() protoreflect.Message {
+func (x *InitializeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -629,55 +713,63 @@ func (x *CallToolResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CallToolResult.ProtoReflect.Descriptor instead.
-func (*CallToolResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use InitializeRequest.ProtoReflect.Descriptor instead.
+func (*InitializeRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CallToolResult) GetContent() []*ContentBlock {
+func (x *InitializeRequest) GetProtocolVersion() string {
 	if x != nil {
-		return x.Content
+		return x.ProtocolVersion
+	}
+	return ""
+}
+
+func (x *InitializeRequest) GetCapabilities() *ClientCapabilities {
+	if x != nil {
+		return x.Capabilities
 	}
 	return nil
 }
 
-func (x *CallToolResult) GetStructuredContent() *structpb.Struct {
+func (x *InitializeRequest) GetClientInfo() *Implementation {
 	if x != nil {
-		return x.StructuredContent
+		return x.ClientInfo
 	}
 	return nil
 }
 
-func (x *CallToolResult) GetIsError() bool {
-	if x != nil && x.IsError != nil {
-		return *x.IsError
+func (x *InitializeRequest) GetRoots() []*Root {
+	if x != nil {
+		return x.Roots
 	}
-	return false
+	return nil
 }
 
-type CompleteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ref           *PromptReference       `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
-	Argument      *CompletionArgument    `protobuf:"bytes,2,opt,name=argument,proto3" json:"argument,omitempty"`
-	Context       *CompletionContext     `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type InitializeResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolVersion string                 `protobuf:"bytes,1,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Capabilities    *ServerCapabilities    `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	ServerInfo      *Implementation        `protobuf:"bytes,3,opt,name=serverInfo,proto3" json:"serverInfo,omitempty"`
+	Instructions    *string                `protobuf:"bytes,4,opt,name=instructions,proto3,oneof" json:"instructions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *CompleteRequest) Reset() {
-	*x = CompleteRequest{}
-	mi := &file_mcp_proto_msgTypes[10]
+func (x *InitializeResult) Reset() {
+	*x = InitializeResult{}
+	mi := &file_mcp_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteRequest) String() string {
+func (x *InitializeResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteRequest) ProtoMessage() {}
+func (*InitializeResult) ProtoMessage() {}
 
-func (x *CompleteRequest) ProtoReflect() protoreflect.Message {
+func (x *InitializeResult) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -689,53 +781,61 @@ func (x *CompleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteRequest.ProtoReflect.Descriptor instead.
-func (*CompleteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use InitializeResult.ProtoReflect.Descriptor instead.
+func (*InitializeResult) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CompleteRequest) GetRef() *PromptReference {
+func (x *InitializeResult) GetProtocolVersion() string {
 	if x != nil {
-		return x.Ref
+		return x.ProtocolVersion
 	}
-	return nil
+	return ""
 }
 
-func (x *CompleteRequest) GetArgument() *CompletionArgument {
+func (x *InitializeResult) GetCapabilities() *ServerCapabilities {
 	if x != nil {
-		return x.Argument
+		return x.Capabilities
 	}
 	return nil
 }
 
-func (x *CompleteRequest) GetContext() *CompletionContext {
+func (x *InitializeResult) GetServerInfo() *Implementation {
 	if x != nil {
-		return x.Context
+		return x.ServerInfo
 	}
 	return nil
 }
 
-type CompleteResult struct {
+func (x *InitializeResult) GetInstructions() string {
+	if x != nil && x.Instructions != nil {
+		return *x.Instructions
+	}
+	return ""
+}
+
+type ListToolsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Completion    *Completion            `protobuf:"bytes,1,opt,name=completion,proto3" json:"completion,omitempty"`
+	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CompleteResult) Reset() {
-	*x = CompleteResult{}
+func (x *ListToolsRequest) Reset() {
+	*x = ListToolsRequest{}
 	mi := &file_mcp_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CompleteResult) String() string {
+func (x *ListToolsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompleteResult) ProtoMessage() {}
+func (*ListToolsRequest) ProtoMessage() {}
 
-func (x *CompleteResult) ProtoReflect() protoreflect.Message {
+func (x *ListToolsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -747,38 +847,48 @@ func (x *CompleteResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompleteResult.ProtoReflect.Descriptor instead.
-func (*CompleteResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListToolsRequest.ProtoReflect.Descriptor instead.
+func (*ListToolsRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CompleteResult) GetCompletion() *Completion {
+func (x *ListToolsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListToolsRequest) GetXMeta() *structpb.Struct {
 	if x != nil {
-		return x.Completion
+		return x.XMeta
 	}
 	return nil
 }
 
-type PingRequest struct {
+type ListToolsResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tools         []*Tool                `protobuf:"bytes,1,rep,name=tools,proto3" json:"tools,omitempty"`
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=nextCursor,proto3,oneof" json:"nextCursor,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingRequest) Reset() {
-	*x = PingRequest{}
+func (x *ListToolsResult) Reset() {
+	*x = ListToolsResult{}
 	mi := &file_mcp_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingRequest) String() string {
+func (x *ListToolsResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingRequest) ProtoMessage() {}
+func (*ListToolsResult) ProtoMessage() {}
 
-func (x *PingRequest) ProtoReflect() protoreflect.Message {
+func (x *ListToolsResult) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -790,31 +900,55 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
-func (*PingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListToolsResult.ProtoReflect.Descriptor instead.
+func (*ListToolsResult) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{12}
 }
 
-type PingResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *ListToolsResult) GetTools() []*Tool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *ListToolsResult) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListToolsResult) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type CallToolRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Name          string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Arguments     map[string]*structpb.Value `protobuf:"bytes,2,rep,name=arguments,proto3" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XMeta         *structpb.Struct           `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingResult) Reset() {
-	*x = PingResult{}
+func (x *CallToolRequest) Reset() {
+	*x = CallToolRequest{}
 	mi := &file_mcp_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingResult) String() string {
+func (x *CallToolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingResult) ProtoMessage() {}
+func (*CallToolRequest) ProtoMessage() {}
 
-func (x *PingResult) ProtoReflect() protoreflect.Message {
+func (x *CallToolRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -826,33 +960,55 @@ func (x *PingResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingResult.ProtoReflect.Descriptor instead.
-func (*PingResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use CallToolRequest.ProtoReflect.Descriptor instead.
+func (*CallToolRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{13}
 }
 
-type ListPromptsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
-	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CallToolRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *ListPromptsRequest) Reset() {
-	*x = ListPromptsRequest{}
+func (x *CallToolRequest) GetArguments() map[string]*structpb.Value {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *CallToolRequest) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type CallToolResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Content           []*ContentBlock        `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
+	StructuredContent *structpb.Struct       `protobuf:"bytes,2,opt,name=structuredContent,proto3,oneof" json:"structuredContent,omitempty"`
+	IsError           *bool                  `protobuf:"varint,3,opt,name=isError,proto3,oneof" json:"isError,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CallToolResult) Reset() {
+	*x = CallToolResult{}
 	mi := &file_mcp_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPromptsRequest) String() string {
+func (x *CallToolResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPromptsRequest) ProtoMessage() {}
+func (*CallToolResult) ProtoMessage() {}
 
-func (x *ListPromptsRequest) ProtoReflect() protoreflect.Message {
+func (x *CallToolResult) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -864,48 +1020,58 @@ func (x *ListPromptsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPromptsRequest.ProtoReflect.Descriptor instead.
-func (*ListPromptsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CallToolResult.ProtoReflect.Descriptor instead.
+func (*CallToolResult) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListPromptsRequest) GetCursor() string {
-	if x != nil && x.Cursor != nil {
-		return *x.Cursor
+func (x *CallToolResult) GetContent() []*ContentBlock {
+	if x != nil {
+		return x.Content
 	}
-	return ""
+	return nil
 }
 
-func (x *ListPromptsRequest) GetXMeta() *structpb.Struct {
+func (x *CallToolResult) GetStructuredContent() *structpb.Struct {
 	if x != nil {
-		return x.XMeta
+		return x.StructuredContent
 	}
 	return nil
 }
 
-type ListPromptsResult struct {
+func (x *CallToolResult) GetIsError() bool {
+	if x != nil && x.IsError != nil {
+		return *x.IsError
+	}
+	return false
+}
+
+// CallToolBatchRequest calls several tools at once. A session that negotiated MCP
+// 2025-03-26, the only revision with JSON-RPC batching, sends them as one batch, any
+// other makes the calls side by side. Sessions the proxy opens itself ask for
+// 2025-06-18, so only an Initialize asking for 2025-03-26, or that revision in the
+// mcp-protocol-version header, gets real batches.
+type CallToolBatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prompts       []*Prompt              `protobuf:"bytes,1,rep,name=prompts,proto3" json:"prompts,omitempty"`
-	NextCursor    *string                `protobuf:"bytes,2,opt,name=nextCursor,proto3,oneof" json:"nextCursor,omitempty"`
-	XMeta         *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	Requests      []*CallToolRequest     `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPromptsResult) Reset() {
-	*x = ListPromptsResult{}
+func (x *CallToolBatchRequest) Reset() {
+	*x = CallToolBatchRequest{}
 	mi := &file_mcp_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPromptsResult) String() string {
+func (x *CallToolBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPromptsResult) ProtoMessage() {}
+func (*CallToolBatchRequest) ProtoMessage() {}
 
-func (x *ListPromptsResult) ProtoReflect() protoreflect.Message {
+func (x *CallToolBatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -917,54 +1083,40 @@ func (x *ListPromptsResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPromptsResult.ProtoReflect.Descriptor instead.
-func (*ListPromptsResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use CallToolBatchRequest.ProtoReflect.Descriptor instead.
+func (*CallToolBatchRequest) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListPromptsResult) GetPrompts() []*Prompt {
-	if x != nil {
-		return x.Prompts
-	}
-	return nil
-}
-
-func (x *ListPromptsResult) GetNextCursor() string {
-	if x != nil && x.NextCursor != nil {
-		return *x.NextCursor
-	}
-	return ""
-}
-
-func (x *ListPromptsResult) GetXMeta() *structpb.Struct {
+func (x *CallToolBatchRequest) GetRequests() []*CallToolRequest {
 	if x != nil {
-		return x.XMeta
+		return x.Requests
 	}
 	return nil
 }
 
-type GetPromptRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+// CallToolBatchResult holds a response for each request, in the order they were made.
+type CallToolBatchResult struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Responses     []*CallToolBatchResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPromptRequest) Reset() {
-	*x = GetPromptRequest{}
+func (x *CallToolBatchResult) Reset() {
+	*x = CallToolBatchResult{}
 	mi := &file_mcp_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPromptRequest) String() string {
+func (x *CallToolBatchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPromptRequest) ProtoMessage() {}
+func (*CallToolBatchResult) ProtoMessage() {}
 
-func (x *GetPromptRequest) ProtoReflect() protoreflect.Message {
+func (x *CallToolBatchResult) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -976,47 +1128,45 @@ func (x *GetPromptRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPromptRequest.ProtoReflect.Descriptor instead.
-func (*GetPromptRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CallToolBatchResult.ProtoReflect.Descriptor instead.
+func (*CallToolBatchResult) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GetPromptRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *GetPromptRequest) GetXMeta() *structpb.Struct {
+func (x *CallToolBatchResult) GetResponses() []*CallToolBatchResponse {
 	if x != nil {
-		return x.XMeta
+		return x.Responses
 	}
 	return nil
 }
 
-type GetPromptResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prompt        *Prompt                `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`
-	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+// CallToolBatchResponse is either a call's result or the error CallMethod would have
+// failed with, code being its gRPC status code.
+type CallToolBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*CallToolBatchResponse_Result
+	//	*CallToolBatchResponse_Error
+	Response      isCallToolBatchResponse_Response `protobuf_oneof:"response"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPromptResult) Reset() {
-	*x = GetPromptResult{}
+func (x *CallToolBatchResponse) Reset() {
+	*x = CallToolBatchResponse{}
 	mi := &file_mcp_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPromptResult) String() string {
+func (x *CallToolBatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPromptResult) ProtoMessage() {}
+func (*CallToolBatchResponse) ProtoMessage() {}
 
-func (x *GetPromptResult) ProtoReflect() protoreflect.Message {
+func (x *CallToolBatchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mcp_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1028,51 +1178,2078 @@ func (x *GetPromptResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPromptResult.ProtoReflect.Descriptor instead.
-func (*GetPromptResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use CallToolBatchResponse.ProtoReflect.Descriptor instead.
+func (*CallToolBatchResponse) Descriptor() ([]byte, []int) {
 	return file_mcp_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetPromptResult) GetPrompt() *Prompt {
+func (x *CallToolBatchResponse) GetResponse() isCallToolBatchResponse_Response {
 	if x != nil {
-		return x.Prompt
+		return x.Response
 	}
 	return nil
 }
 
-func (x *GetPromptResult) GetXMeta() *structpb.Struct {
+func (x *CallToolBatchResponse) GetResult() *CallToolResult {
 	if x != nil {
-		return x.XMeta
+		if x, ok := x.Response.(*CallToolBatchResponse_Result); ok {
+			return x.Result
+		}
 	}
 	return nil
 }
 
-type Prompt struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+func (x *CallToolBatchResponse) GetError() *CallError {
+	if x != nil {
+		if x, ok := x.Response.(*CallToolBatchResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isCallToolBatchResponse_Response interface {
+	isCallToolBatchResponse_Response()
+}
+
+type CallToolBatchResponse_Result struct {
+	Result *CallToolResult `protobuf:"bytes,1,opt,name=result,proto3,oneof"`
+}
+
+type CallToolBatchResponse_Error struct {
+	Error *CallError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CallToolBatchResponse_Result) isCallToolBatchResponse_Response() {}
+
+func (*CallToolBatchResponse_Error) isCallToolBatchResponse_Response() {}
+
+type CallError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallError) Reset() {
+	*x = CallError{}
+	mi := &file_mcp_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallError) ProtoMessage() {}
+
+func (x *CallError) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallError.ProtoReflect.Descriptor instead.
+func (*CallError) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CallError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CallError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// CallToolProgress is one event from a running tool, any number of progress and log
+// messages followed by the result.
+type CallToolProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*CallToolProgress_Progress
+	//	*CallToolProgress_Log
+	//	*CallToolProgress_Result
+	Event         isCallToolProgress_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallToolProgress) Reset() {
+	*x = CallToolProgress{}
+	mi := &file_mcp_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallToolProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallToolProgress) ProtoMessage() {}
+
+func (x *CallToolProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallToolProgress.ProtoReflect.Descriptor instead.
+func (*CallToolProgress) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CallToolProgress) GetEvent() isCallToolProgress_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *CallToolProgress) GetProgress() *ProgressNotification {
+	if x != nil {
+		if x, ok := x.Event.(*CallToolProgress_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *CallToolProgress) GetLog() *LoggingMessageNotification {
+	if x != nil {
+		if x, ok := x.Event.(*CallToolProgress_Log); ok {
+			return x.Log
+		}
+	}
+	return nil
+}
+
+func (x *CallToolProgress) GetResult() *CallToolResult {
+	if x != nil {
+		if x, ok := x.Event.(*CallToolProgress_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isCallToolProgress_Event interface {
+	isCallToolProgress_Event()
+}
+
+type CallToolProgress_Progress struct {
+	Progress *ProgressNotification `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type CallToolProgress_Log struct {
+	Log *LoggingMessageNotification `protobuf:"bytes,2,opt,name=log,proto3,oneof"`
+}
+
+type CallToolProgress_Result struct {
+	Result *CallToolResult `protobuf:"bytes,3,opt,name=result,proto3,oneof"`
+}
+
+func (*CallToolProgress_Progress) isCallToolProgress_Event() {}
+
+func (*CallToolProgress_Log) isCallToolProgress_Event() {}
+
+func (*CallToolProgress_Result) isCallToolProgress_Event() {}
+
+type ProgressNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProgressToken *structpb.Value        `protobuf:"bytes,1,opt,name=progressToken,proto3" json:"progressToken,omitempty"`
+	Progress      float64                `protobuf:"fixed64,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	Total         *float64               `protobuf:"fixed64,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	Message       *string                `protobuf:"bytes,4,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressNotification) Reset() {
+	*x = ProgressNotification{}
+	mi := &file_mcp_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressNotification) ProtoMessage() {}
+
+func (x *ProgressNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressNotification.ProtoReflect.Descriptor instead.
+func (*ProgressNotification) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ProgressNotification) GetProgressToken() *structpb.Value {
+	if x != nil {
+		return x.ProgressToken
+	}
+	return nil
+}
+
+func (x *ProgressNotification) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *ProgressNotification) GetTotal() float64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *ProgressNotification) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type LoggingMessageNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         LoggingLevel           `protobuf:"varint,1,opt,name=level,proto3,enum=mcp.LoggingLevel" json:"level,omitempty"`
+	Logger        *string                `protobuf:"bytes,2,opt,name=logger,proto3,oneof" json:"logger,omitempty"`
+	Data          *structpb.Value        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoggingMessageNotification) Reset() {
+	*x = LoggingMessageNotification{}
+	mi := &file_mcp_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoggingMessageNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggingMessageNotification) ProtoMessage() {}
+
+func (x *LoggingMessageNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggingMessageNotification.ProtoReflect.Descriptor instead.
+func (*LoggingMessageNotification) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *LoggingMessageNotification) GetLevel() LoggingLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LoggingLevel_LOGGING_LEVEL_UNSPECIFIED
+}
+
+func (x *LoggingMessageNotification) GetLogger() string {
+	if x != nil && x.Logger != nil {
+		return *x.Logger
+	}
+	return ""
+}
+
+func (x *LoggingMessageNotification) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SetLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         LoggingLevel           `protobuf:"varint,1,opt,name=level,proto3,enum=mcp.LoggingLevel" json:"level,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLevelRequest) Reset() {
+	*x = SetLevelRequest{}
+	mi := &file_mcp_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLevelRequest) ProtoMessage() {}
+
+func (x *SetLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLevelRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetLevelRequest) GetLevel() LoggingLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LoggingLevel_LOGGING_LEVEL_UNSPECIFIED
+}
+
+func (x *SetLevelRequest) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type SetLevelResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLevelResult) Reset() {
+	*x = SetLevelResult{}
+	mi := &file_mcp_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLevelResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLevelResult) ProtoMessage() {}
+
+func (x *SetLevelResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLevelResult.ProtoReflect.Descriptor instead.
+func (*SetLevelResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{23}
+}
+
+// StreamLogsRequest streams the log messages the server sends on the session's own
+// stream. level, if given, is set once the stream is open so nothing is missed.
+type StreamLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         *LoggingLevel          `protobuf:"varint,1,opt,name=level,proto3,enum=mcp.LoggingLevel,oneof" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_mcp_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StreamLogsRequest) GetLevel() LoggingLevel {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return LoggingLevel_LOGGING_LEVEL_UNSPECIFIED
+}
+
+type WatchCatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCatalogRequest) Reset() {
+	*x = WatchCatalogRequest{}
+	mi := &file_mcp_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCatalogRequest) ProtoMessage() {}
+
+func (x *WatchCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCatalogRequest.ProtoReflect.Descriptor instead.
+func (*WatchCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{25}
+}
+
+// CatalogChange is sent when the server says one of its lists changed, carrying the
+// list as fetched again, every page of it.
+type CatalogChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Catalog:
+	//
+	//	*CatalogChange_Tools
+	//	*CatalogChange_Prompts
+	//	*CatalogChange_Resources
+	Catalog       isCatalogChange_Catalog `protobuf_oneof:"catalog"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogChange) Reset() {
+	*x = CatalogChange{}
+	mi := &file_mcp_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogChange) ProtoMessage() {}
+
+func (x *CatalogChange) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogChange.ProtoReflect.Descriptor instead.
+func (*CatalogChange) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CatalogChange) GetCatalog() isCatalogChange_Catalog {
+	if x != nil {
+		return x.Catalog
+	}
+	return nil
+}
+
+func (x *CatalogChange) GetTools() *ListToolsResult {
+	if x != nil {
+		if x, ok := x.Catalog.(*CatalogChange_Tools); ok {
+			return x.Tools
+		}
+	}
+	return nil
+}
+
+func (x *CatalogChange) GetPrompts() *ListPromptsResult {
+	if x != nil {
+		if x, ok := x.Catalog.(*CatalogChange_Prompts); ok {
+			return x.Prompts
+		}
+	}
+	return nil
+}
+
+func (x *CatalogChange) GetResources() *ListResourcesResult {
+	if x != nil {
+		if x, ok := x.Catalog.(*CatalogChange_Resources); ok {
+			return x.Resources
+		}
+	}
+	return nil
+}
+
+type isCatalogChange_Catalog interface {
+	isCatalogChange_Catalog()
+}
+
+type CatalogChange_Tools struct {
+	Tools *ListToolsResult `protobuf:"bytes,1,opt,name=tools,proto3,oneof"`
+}
+
+type CatalogChange_Prompts struct {
+	Prompts *ListPromptsResult `protobuf:"bytes,2,opt,name=prompts,proto3,oneof"`
+}
+
+type CatalogChange_Resources struct {
+	Resources *ListResourcesResult `protobuf:"bytes,3,opt,name=resources,proto3,oneof"`
+}
+
+func (*CatalogChange_Tools) isCatalogChange_Catalog() {}
+
+func (*CatalogChange_Prompts) isCatalogChange_Catalog() {}
+
+func (*CatalogChange_Resources) isCatalogChange_Catalog() {}
+
+// ServerRequest is a request the MCP server made of the client. The client answers it
+// with a ClientResponse carrying the same requestId.
+type ServerRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*ServerRequest_CreateMessage
+	//	*ServerRequest_Elicit
+	Request       isServerRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerRequest) Reset() {
+	*x = ServerRequest{}
+	mi := &file_mcp_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerRequest) ProtoMessage() {}
+
+func (x *ServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerRequest.ProtoReflect.Descriptor instead.
+func (*ServerRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ServerRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ServerRequest) GetRequest() isServerRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *ServerRequest) GetCreateMessage() *CreateMessageRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ServerRequest_CreateMessage); ok {
+			return x.CreateMessage
+		}
+	}
+	return nil
+}
+
+func (x *ServerRequest) GetElicit() *ElicitRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ServerRequest_Elicit); ok {
+			return x.Elicit
+		}
+	}
+	return nil
+}
+
+type isServerRequest_Request interface {
+	isServerRequest_Request()
+}
+
+type ServerRequest_CreateMessage struct {
+	CreateMessage *CreateMessageRequest `protobuf:"bytes,2,opt,name=createMessage,proto3,oneof"`
+}
+
+type ServerRequest_Elicit struct {
+	Elicit *ElicitRequest `protobuf:"bytes,3,opt,name=elicit,proto3,oneof"`
+}
+
+func (*ServerRequest_CreateMessage) isServerRequest_Request() {}
+
+func (*ServerRequest_Elicit) isServerRequest_Request() {}
+
+// ClientResponse answers the ServerRequest with the same requestId, with either its
+// result or an error saying why the client won't.
+type ClientResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ClientResponse_CreateMessage
+	//	*ClientResponse_Error
+	//	*ClientResponse_Elicit
+	Response      isClientResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientResponse) Reset() {
+	*x = ClientResponse{}
+	mi := &file_mcp_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientResponse) ProtoMessage() {}
+
+func (x *ClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientResponse.ProtoReflect.Descriptor instead.
+func (*ClientResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ClientResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ClientResponse) GetResponse() isClientResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ClientResponse) GetCreateMessage() *CreateMessageResult {
+	if x != nil {
+		if x, ok := x.Response.(*ClientResponse_CreateMessage); ok {
+			return x.CreateMessage
+		}
+	}
+	return nil
+}
+
+func (x *ClientResponse) GetError() *ClientError {
+	if x != nil {
+		if x, ok := x.Response.(*ClientResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *ClientResponse) GetElicit() *ElicitResult {
+	if x != nil {
+		if x, ok := x.Response.(*ClientResponse_Elicit); ok {
+			return x.Elicit
+		}
+	}
+	return nil
+}
+
+type isClientResponse_Response interface {
+	isClientResponse_Response()
+}
+
+type ClientResponse_CreateMessage struct {
+	CreateMessage *CreateMessageResult `protobuf:"bytes,2,opt,name=createMessage,proto3,oneof"`
+}
+
+type ClientResponse_Error struct {
+	Error *ClientError `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+type ClientResponse_Elicit struct {
+	Elicit *ElicitResult `protobuf:"bytes,4,opt,name=elicit,proto3,oneof"`
+}
+
+func (*ClientResponse_CreateMessage) isClientResponse_Response() {}
+
+func (*ClientResponse_Error) isClientResponse_Response() {}
+
+func (*ClientResponse_Elicit) isClientResponse_Response() {}
+
+type ClientError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientError) Reset() {
+	*x = ClientError{}
+	mi := &file_mcp_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientError) ProtoMessage() {}
+
+func (x *ClientError) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientError.ProtoReflect.Descriptor instead.
+func (*ClientError) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ClientError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ClientError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateMessageRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Messages         []*SamplingMessage     `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	ModelPreferences *ModelPreferences      `protobuf:"bytes,2,opt,name=modelPreferences,proto3,oneof" json:"modelPreferences,omitempty"`
+	SystemPrompt     *string                `protobuf:"bytes,3,opt,name=systemPrompt,proto3,oneof" json:"systemPrompt,omitempty"`
+	IncludeContext   *string                `protobuf:"bytes,4,opt,name=includeContext,proto3,oneof" json:"includeContext,omitempty"`
+	Temperature      *float64               `protobuf:"fixed64,5,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	MaxTokens        int64                  `protobuf:"varint,6,opt,name=maxTokens,proto3" json:"maxTokens,omitempty"`
+	StopSequences    []string               `protobuf:"bytes,7,rep,name=stopSequences,proto3" json:"stopSequences,omitempty"`
+	Metadata         *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
+	XMeta            *structpb.Struct       `protobuf:"bytes,9,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateMessageRequest) Reset() {
+	*x = CreateMessageRequest{}
+	mi := &file_mcp_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMessageRequest) ProtoMessage() {}
+
+func (x *CreateMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMessageRequest.ProtoReflect.Descriptor instead.
+func (*CreateMessageRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateMessageRequest) GetMessages() []*SamplingMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *CreateMessageRequest) GetModelPreferences() *ModelPreferences {
+	if x != nil {
+		return x.ModelPreferences
+	}
+	return nil
+}
+
+func (x *CreateMessageRequest) GetSystemPrompt() string {
+	if x != nil && x.SystemPrompt != nil {
+		return *x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *CreateMessageRequest) GetIncludeContext() string {
+	if x != nil && x.IncludeContext != nil {
+		return *x.IncludeContext
+	}
+	return ""
+}
+
+func (x *CreateMessageRequest) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *CreateMessageRequest) GetMaxTokens() int64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *CreateMessageRequest) GetStopSequences() []string {
+	if x != nil {
+		return x.StopSequences
+	}
+	return nil
+}
+
+func (x *CreateMessageRequest) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateMessageRequest) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type CreateMessageResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=mcp.Role" json:"role,omitempty"`
+	Content       *ContentBlock          `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	StopReason    *string                `protobuf:"bytes,4,opt,name=stopReason,proto3,oneof" json:"stopReason,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,5,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMessageResult) Reset() {
+	*x = CreateMessageResult{}
+	mi := &file_mcp_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMessageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMessageResult) ProtoMessage() {}
+
+func (x *CreateMessageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMessageResult.ProtoReflect.Descriptor instead.
+func (*CreateMessageResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateMessageResult) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *CreateMessageResult) GetContent() *ContentBlock {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *CreateMessageResult) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateMessageResult) GetStopReason() string {
+	if x != nil && x.StopReason != nil {
+		return *x.StopReason
+	}
+	return ""
+}
+
+func (x *CreateMessageResult) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+// ElicitRequest asks the user for input, shaped by requestedSchema, a JSON schema
+// object whose properties are all primitives.
+type ElicitRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Message         string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	RequestedSchema *structpb.Struct       `protobuf:"bytes,2,opt,name=requestedSchema,proto3" json:"requestedSchema,omitempty"`
+	XMeta           *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ElicitRequest) Reset() {
+	*x = ElicitRequest{}
+	mi := &file_mcp_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ElicitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ElicitRequest) ProtoMessage() {}
+
+func (x *ElicitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ElicitRequest.ProtoReflect.Descriptor instead.
+func (*ElicitRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ElicitRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ElicitRequest) GetRequestedSchema() *structpb.Struct {
+	if x != nil {
+		return x.RequestedSchema
+	}
+	return nil
+}
+
+func (x *ElicitRequest) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+// ElicitResult gives the user's input as content when they accept.
+type ElicitResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        ElicitAction           `protobuf:"varint,1,opt,name=action,proto3,enum=mcp.ElicitAction" json:"action,omitempty"`
+	Content       *structpb.Struct       `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ElicitResult) Reset() {
+	*x = ElicitResult{}
+	mi := &file_mcp_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ElicitResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ElicitResult) ProtoMessage() {}
+
+func (x *ElicitResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ElicitResult.ProtoReflect.Descriptor instead.
+func (*ElicitResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ElicitResult) GetAction() ElicitAction {
+	if x != nil {
+		return x.Action
+	}
+	return ElicitAction_ELICIT_ACTION_UNSPECIFIED
+}
+
+func (x *ElicitResult) GetContent() *structpb.Struct {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ElicitResult) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type CompleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           *Reference             `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`
+	Argument      *CompletionArgument    `protobuf:"bytes,2,opt,name=argument,proto3" json:"argument,omitempty"`
+	Context       *CompletionContext     `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteRequest) Reset() {
+	*x = CompleteRequest{}
+	mi := &file_mcp_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteRequest) ProtoMessage() {}
+
+func (x *CompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteRequest.ProtoReflect.Descriptor instead.
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CompleteRequest) GetRef() *Reference {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *CompleteRequest) GetArgument() *CompletionArgument {
+	if x != nil {
+		return x.Argument
+	}
+	return nil
+}
+
+func (x *CompleteRequest) GetContext() *CompletionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+type CompleteResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Completion    *Completion            `protobuf:"bytes,1,opt,name=completion,proto3" json:"completion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteResult) Reset() {
+	*x = CompleteResult{}
+	mi := &file_mcp_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteResult) ProtoMessage() {}
+
+func (x *CompleteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteResult.ProtoReflect.Descriptor instead.
+func (*CompleteResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CompleteResult) GetCompletion() *Completion {
+	if x != nil {
+		return x.Completion
+	}
+	return nil
+}
+
+// SetRootsRequest replaces the session's roots, telling the server they changed.
+type SetRootsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roots         []*Root                `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRootsRequest) Reset() {
+	*x = SetRootsRequest{}
+	mi := &file_mcp_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRootsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRootsRequest) ProtoMessage() {}
+
+func (x *SetRootsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRootsRequest.ProtoReflect.Descriptor instead.
+func (*SetRootsRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetRootsRequest) GetRoots() []*Root {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+type SetRootsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRootsResult) Reset() {
+	*x = SetRootsResult{}
+	mi := &file_mcp_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRootsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRootsResult) ProtoMessage() {}
+
+func (x *SetRootsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRootsResult.ProtoReflect.Descriptor instead.
+func (*SetRootsResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{37}
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_mcp_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{38}
+}
+
+type PingResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResult) Reset() {
+	*x = PingResult{}
+	mi := &file_mcp_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResult) ProtoMessage() {}
+
+func (x *PingResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResult.ProtoReflect.Descriptor instead.
+func (*PingResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{39}
+}
+
+// TerminateRequest ends the session named by the mcp-session-id header.
+type TerminateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateRequest) Reset() {
+	*x = TerminateRequest{}
+	mi := &file_mcp_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateRequest) ProtoMessage() {}
+
+func (x *TerminateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateRequest.ProtoReflect.Descriptor instead.
+func (*TerminateRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{40}
+}
+
+type TerminateResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateResult) Reset() {
+	*x = TerminateResult{}
+	mi := &file_mcp_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateResult) ProtoMessage() {}
+
+func (x *TerminateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateResult.ProtoReflect.Descriptor instead.
+func (*TerminateResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{41}
+}
+
+type ListPromptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        *string                `protobuf:"bytes,1,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptsRequest) Reset() {
+	*x = ListPromptsRequest{}
+	mi := &file_mcp_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptsRequest) ProtoMessage() {}
+
+func (x *ListPromptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromptsRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListPromptsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPromptsRequest) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type ListPromptsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prompts       []*Prompt              `protobuf:"bytes,1,rep,name=prompts,proto3" json:"prompts,omitempty"`
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=nextCursor,proto3,oneof" json:"nextCursor,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptsResult) Reset() {
+	*x = ListPromptsResult{}
+	mi := &file_mcp_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptsResult) ProtoMessage() {}
+
+func (x *ListPromptsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptsResult.ProtoReflect.Descriptor instead.
+func (*ListPromptsResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListPromptsResult) GetPrompts() []*Prompt {
+	if x != nil {
+		return x.Prompts
+	}
+	return nil
+}
+
+func (x *ListPromptsResult) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListPromptsResult) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+type GetPromptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	Arguments     map[string]string      `protobuf:"bytes,3,rep,name=arguments,proto3" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromptRequest) Reset() {
+	*x = GetPromptRequest{}
+	mi := &file_mcp_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromptRequest) ProtoMessage() {}
+
+func (x *GetPromptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromptRequest.ProtoReflect.Descriptor instead.
+func (*GetPromptRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetPromptRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetPromptRequest) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+func (x *GetPromptRequest) GetArguments() map[string]string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+type GetPromptResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,2,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Messages      []*PromptMessage       `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromptResult) Reset() {
+	*x = GetPromptResult{}
+	mi := &file_mcp_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromptResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromptResult) ProtoMessage() {}
+
+func (x *GetPromptResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromptResult.ProtoReflect.Descriptor instead.
+func (*GetPromptResult) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetPromptResult) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+func (x *GetPromptResult) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *GetPromptResult) GetMessages() []*PromptMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type Prompt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
 	Content       []*ContentBlock        `protobuf:"bytes,3,rep,name=content,proto3" json:"content,omitempty"`
 	Params        map[string]*JSONSchema `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	XMeta         *structpb.Struct       `protobuf:"bytes,5,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	Description   *string                `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Arguments     []*PromptArgument      `protobuf:"bytes,7,rep,name=arguments,proto3" json:"arguments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Prompt) Reset() {
 	*x = Prompt{}
-	mi := &file_mcp_proto_msgTypes[18]
+	mi := &file_mcp_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prompt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prompt) ProtoMessage() {}
+
+func (x *Prompt) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prompt.ProtoReflect.Descriptor instead.
+func (*Prompt) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Prompt) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Prompt) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *Prompt) GetContent() []*ContentBlock {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Prompt) GetParams() map[string]*JSONSchema {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *Prompt) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
+func (x *Prompt) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Prompt) GetArguments() []*PromptArgument {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+type PromptArgument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Required      *bool                  `protobuf:"varint,4,opt,name=required,proto3,oneof" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptArgument) Reset() {
+	*x = PromptArgument{}
+	mi := &file_mcp_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptArgument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptArgument) ProtoMessage() {}
+
+func (x *PromptArgument) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptArgument.ProtoReflect.Descriptor instead.
+func (*PromptArgument) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PromptArgument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromptArgument) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *PromptArgument) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *PromptArgument) GetRequired() bool {
+	if x != nil && x.Required != nil {
+		return *x.Required
+	}
+	return false
+}
+
+type PromptMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=mcp.Role" json:"role,omitempty"`
+	Content       *ContentBlock          `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptMessage) Reset() {
+	*x = PromptMessage{}
+	mi := &file_mcp_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptMessage) ProtoMessage() {}
+
+func (x *PromptMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptMessage.ProtoReflect.Descriptor instead.
+func (*PromptMessage) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PromptMessage) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *PromptMessage) GetContent() *ContentBlock {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// SamplingMessage content can only be text, image or audio
+type SamplingMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=mcp.Role" json:"role,omitempty"`
+	Content       *ContentBlock          `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SamplingMessage) Reset() {
+	*x = SamplingMessage{}
+	mi := &file_mcp_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SamplingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplingMessage) ProtoMessage() {}
+
+func (x *SamplingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplingMessage.ProtoReflect.Descriptor instead.
+func (*SamplingMessage) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SamplingMessage) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *SamplingMessage) GetContent() *ContentBlock {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ModelPreferences struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Hints                []*ModelHint           `protobuf:"bytes,1,rep,name=hints,proto3" json:"hints,omitempty"`
+	CostPriority         *float64               `protobuf:"fixed64,2,opt,name=costPriority,proto3,oneof" json:"costPriority,omitempty"`
+	SpeedPriority        *float64               `protobuf:"fixed64,3,opt,name=speedPriority,proto3,oneof" json:"speedPriority,omitempty"`
+	IntelligencePriority *float64               `protobuf:"fixed64,4,opt,name=intelligencePriority,proto3,oneof" json:"intelligencePriority,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ModelPreferences) Reset() {
+	*x = ModelPreferences{}
+	mi := &file_mcp_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelPreferences) ProtoMessage() {}
+
+func (x *ModelPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelPreferences.ProtoReflect.Descriptor instead.
+func (*ModelPreferences) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ModelPreferences) GetHints() []*ModelHint {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+func (x *ModelPreferences) GetCostPriority() float64 {
+	if x != nil && x.CostPriority != nil {
+		return *x.CostPriority
+	}
+	return 0
+}
+
+func (x *ModelPreferences) GetSpeedPriority() float64 {
+	if x != nil && x.SpeedPriority != nil {
+		return *x.SpeedPriority
+	}
+	return 0
+}
+
+func (x *ModelPreferences) GetIntelligencePriority() float64 {
+	if x != nil && x.IntelligencePriority != nil {
+		return *x.IntelligencePriority
+	}
+	return 0
+}
+
+type ModelHint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelHint) Reset() {
+	*x = ModelHint{}
+	mi := &file_mcp_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Prompt) String() string {
+func (x *ModelHint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Prompt) ProtoMessage() {}
+func (*ModelHint) ProtoMessage() {}
 
-func (x *Prompt) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[18]
+func (x *ModelHint) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,46 +3260,18 @@ func (x *Prompt) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Prompt.ProtoReflect.Descriptor instead.
-func (*Prompt) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *Prompt) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use ModelHint.ProtoReflect.Descriptor instead.
+func (*ModelHint) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *Prompt) GetTitle() string {
-	if x != nil && x.Title != nil {
-		return *x.Title
+func (x *ModelHint) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (x *Prompt) GetContent() []*ContentBlock {
-	if x != nil {
-		return x.Content
-	}
-	return nil
-}
-
-func (x *Prompt) GetParams() map[string]*JSONSchema {
-	if x != nil {
-		return x.Params
-	}
-	return nil
-}
-
-func (x *Prompt) GetXMeta() *structpb.Struct {
-	if x != nil {
-		return x.XMeta
-	}
-	return nil
-}
-
 type ClientCapabilities struct {
 	state         protoimpl.MessageState      `protogen:"open.v1"`
 	Experimental  map[string]*structpb.Struct `protobuf:"bytes,1,rep,name=experimental,proto3" json:"experimental,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -1135,7 +3284,7 @@ type ClientCapabilities struct {
 
 func (x *ClientCapabilities) Reset() {
 	*x = ClientCapabilities{}
-	mi := &file_mcp_proto_msgTypes[19]
+	mi := &file_mcp_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1147,7 +3296,7 @@ func (x *ClientCapabilities) String() string {
 func (*ClientCapabilities) ProtoMessage() {}
 
 func (x *ClientCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[19]
+	mi := &file_mcp_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1160,7 +3309,7 @@ func (x *ClientCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientCapabilities.ProtoReflect.Descriptor instead.
 func (*ClientCapabilities) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{19}
+	return file_mcp_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *ClientCapabilities) GetExperimental() map[string]*structpb.Struct {
@@ -1205,7 +3354,7 @@ type ServerCapabilities struct {
 
 func (x *ServerCapabilities) Reset() {
 	*x = ServerCapabilities{}
-	mi := &file_mcp_proto_msgTypes[20]
+	mi := &file_mcp_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1217,7 +3366,7 @@ func (x *ServerCapabilities) String() string {
 func (*ServerCapabilities) ProtoMessage() {}
 
 func (x *ServerCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[20]
+	mi := &file_mcp_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,7 +3379,7 @@ func (x *ServerCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerCapabilities.ProtoReflect.Descriptor instead.
 func (*ServerCapabilities) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{20}
+	return file_mcp_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *ServerCapabilities) GetExperimental() map[string]*structpb.Struct {
@@ -1275,6 +3424,67 @@ func (x *ServerCapabilities) GetTools() *ToolsCapability {
 	return nil
 }
 
+// Root is a directory or file the server may work within, the uri must be file://
+type Root struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	XMeta         *structpb.Struct       `protobuf:"bytes,3,opt,name=_meta,json=Meta,proto3,oneof" json:"_meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Root) Reset() {
+	*x = Root{}
+	mi := &file_mcp_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Root) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Root) ProtoMessage() {}
+
+func (x *Root) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Root.ProtoReflect.Descriptor instead.
+func (*Root) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Root) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Root) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Root) GetXMeta() *structpb.Struct {
+	if x != nil {
+		return x.XMeta
+	}
+	return nil
+}
+
 type RootsCapability struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ListChanged   *bool                  `protobuf:"varint,1,opt,name=listChanged,proto3,oneof" json:"listChanged,omitempty"`
@@ -1284,7 +3494,7 @@ type RootsCapability struct {
 
 func (x *RootsCapability) Reset() {
 	*x = RootsCapability{}
-	mi := &file_mcp_proto_msgTypes[21]
+	mi := &file_mcp_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1296,7 +3506,7 @@ func (x *RootsCapability) String() string {
 func (*RootsCapability) ProtoMessage() {}
 
 func (x *RootsCapability) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[21]
+	mi := &file_mcp_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,7 +3519,7 @@ func (x *RootsCapability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RootsCapability.ProtoReflect.Descriptor instead.
 func (*RootsCapability) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{21}
+	return file_mcp_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *RootsCapability) GetListChanged() bool {
@@ -1328,7 +3538,7 @@ type PromptsCapability struct {
 
 func (x *PromptsCapability) Reset() {
 	*x = PromptsCapability{}
-	mi := &file_mcp_proto_msgTypes[22]
+	mi := &file_mcp_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1340,7 +3550,7 @@ func (x *PromptsCapability) String() string {
 func (*PromptsCapability) ProtoMessage() {}
 
 func (x *PromptsCapability) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[22]
+	mi := &file_mcp_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1353,7 +3563,7 @@ func (x *PromptsCapability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptsCapability.ProtoReflect.Descriptor instead.
 func (*PromptsCapability) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{22}
+	return file_mcp_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *PromptsCapability) GetListChanged() bool {
@@ -1373,7 +3583,7 @@ type ResourcesCapability struct {
 
 func (x *ResourcesCapability) Reset() {
 	*x = ResourcesCapability{}
-	mi := &file_mcp_proto_msgTypes[23]
+	mi := &file_mcp_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1385,7 +3595,7 @@ func (x *ResourcesCapability) String() string {
 func (*ResourcesCapability) ProtoMessage() {}
 
 func (x *ResourcesCapability) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[23]
+	mi := &file_mcp_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1398,7 +3608,7 @@ func (x *ResourcesCapability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourcesCapability.ProtoReflect.Descriptor instead.
 func (*ResourcesCapability) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{23}
+	return file_mcp_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *ResourcesCapability) GetSubscribe() bool {
@@ -1424,7 +3634,7 @@ type ToolsCapability struct {
 
 func (x *ToolsCapability) Reset() {
 	*x = ToolsCapability{}
-	mi := &file_mcp_proto_msgTypes[24]
+	mi := &file_mcp_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1436,7 +3646,7 @@ func (x *ToolsCapability) String() string {
 func (*ToolsCapability) ProtoMessage() {}
 
 func (x *ToolsCapability) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[24]
+	mi := &file_mcp_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1449,7 +3659,7 @@ func (x *ToolsCapability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolsCapability.ProtoReflect.Descriptor instead.
 func (*ToolsCapability) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{24}
+	return file_mcp_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *ToolsCapability) GetListChanged() bool {
@@ -1470,7 +3680,7 @@ type Implementation struct {
 
 func (x *Implementation) Reset() {
 	*x = Implementation{}
-	mi := &file_mcp_proto_msgTypes[25]
+	mi := &file_mcp_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1482,7 +3692,7 @@ func (x *Implementation) String() string {
 func (*Implementation) ProtoMessage() {}
 
 func (x *Implementation) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[25]
+	mi := &file_mcp_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1495,7 +3705,7 @@ func (x *Implementation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Implementation.ProtoReflect.Descriptor instead.
 func (*Implementation) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{25}
+	return file_mcp_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *Implementation) GetName() string {
@@ -1529,7 +3739,7 @@ type BaseMetadata struct {
 
 func (x *BaseMetadata) Reset() {
 	*x = BaseMetadata{}
-	mi := &file_mcp_proto_msgTypes[26]
+	mi := &file_mcp_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1541,7 +3751,7 @@ func (x *BaseMetadata) String() string {
 func (*BaseMetadata) ProtoMessage() {}
 
 func (x *BaseMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[26]
+	mi := &file_mcp_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1554,7 +3764,7 @@ func (x *BaseMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BaseMetadata.ProtoReflect.Descriptor instead.
 func (*BaseMetadata) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{26}
+	return file_mcp_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *BaseMetadata) GetName() string {
@@ -1586,7 +3796,7 @@ type Tool struct {
 
 func (x *Tool) Reset() {
 	*x = Tool{}
-	mi := &file_mcp_proto_msgTypes[27]
+	mi := &file_mcp_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1598,7 +3808,7 @@ func (x *Tool) String() string {
 func (*Tool) ProtoMessage() {}
 
 func (x *Tool) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[27]
+	mi := &file_mcp_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1611,7 +3821,7 @@ func (x *Tool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tool.ProtoReflect.Descriptor instead.
 func (*Tool) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{27}
+	return file_mcp_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *Tool) GetName() string {
@@ -1674,7 +3884,7 @@ type JSONSchema struct {
 
 func (x *JSONSchema) Reset() {
 	*x = JSONSchema{}
-	mi := &file_mcp_proto_msgTypes[28]
+	mi := &file_mcp_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1686,7 +3896,7 @@ func (x *JSONSchema) String() string {
 func (*JSONSchema) ProtoMessage() {}
 
 func (x *JSONSchema) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[28]
+	mi := &file_mcp_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1699,7 +3909,7 @@ func (x *JSONSchema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JSONSchema.ProtoReflect.Descriptor instead.
 func (*JSONSchema) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{28}
+	return file_mcp_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *JSONSchema) GetType() string {
@@ -1736,7 +3946,7 @@ type ToolAnnotations struct {
 
 func (x *ToolAnnotations) Reset() {
 	*x = ToolAnnotations{}
-	mi := &file_mcp_proto_msgTypes[29]
+	mi := &file_mcp_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1748,7 +3958,7 @@ func (x *ToolAnnotations) String() string {
 func (*ToolAnnotations) ProtoMessage() {}
 
 func (x *ToolAnnotations) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[29]
+	mi := &file_mcp_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1761,7 +3971,7 @@ func (x *ToolAnnotations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToolAnnotations.ProtoReflect.Descriptor instead.
 func (*ToolAnnotations) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{29}
+	return file_mcp_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *ToolAnnotations) GetTitle() string {
@@ -1815,7 +4025,7 @@ type ContentBlock struct {
 
 func (x *ContentBlock) Reset() {
 	*x = ContentBlock{}
-	mi := &file_mcp_proto_msgTypes[30]
+	mi := &file_mcp_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1827,7 +4037,7 @@ func (x *ContentBlock) String() string {
 func (*ContentBlock) ProtoMessage() {}
 
 func (x *ContentBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[30]
+	mi := &file_mcp_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1840,7 +4050,7 @@ func (x *ContentBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContentBlock.ProtoReflect.Descriptor instead.
 func (*ContentBlock) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{30}
+	return file_mcp_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *ContentBlock) GetContentType() isContentBlock_ContentType {
@@ -1940,7 +4150,7 @@ type TextContent struct {
 
 func (x *TextContent) Reset() {
 	*x = TextContent{}
-	mi := &file_mcp_proto_msgTypes[31]
+	mi := &file_mcp_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1952,7 +4162,7 @@ func (x *TextContent) String() string {
 func (*TextContent) ProtoMessage() {}
 
 func (x *TextContent) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[31]
+	mi := &file_mcp_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1965,7 +4175,7 @@ func (x *TextContent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextContent.ProtoReflect.Descriptor instead.
 func (*TextContent) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{31}
+	return file_mcp_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *TextContent) GetText() string {
@@ -2001,7 +4211,7 @@ type ImageContent struct {
 
 func (x *ImageContent) Reset() {
 	*x = ImageContent{}
-	mi := &file_mcp_proto_msgTypes[32]
+	mi := &file_mcp_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2013,7 +4223,7 @@ func (x *ImageContent) String() string {
 func (*ImageContent) ProtoMessage() {}
 
 func (x *ImageContent) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[32]
+	mi := &file_mcp_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2026,7 +4236,7 @@ func (x *ImageContent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ImageContent.ProtoReflect.Descriptor instead.
 func (*ImageContent) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{32}
+	return file_mcp_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *ImageContent) GetData() []byte {
@@ -2069,7 +4279,7 @@ type AudioContent struct {
 
 func (x *AudioContent) Reset() {
 	*x = AudioContent{}
-	mi := &file_mcp_proto_msgTypes[33]
+	mi := &file_mcp_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2081,7 +4291,7 @@ func (x *AudioContent) String() string {
 func (*AudioContent) ProtoMessage() {}
 
 func (x *AudioContent) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[33]
+	mi := &file_mcp_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2094,7 +4304,7 @@ func (x *AudioContent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AudioContent.ProtoReflect.Descriptor instead.
 func (*AudioContent) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{33}
+	return file_mcp_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *AudioContent) GetData() []byte {
@@ -2135,7 +4345,7 @@ type ResourceLink struct {
 
 func (x *ResourceLink) Reset() {
 	*x = ResourceLink{}
-	mi := &file_mcp_proto_msgTypes[34]
+	mi := &file_mcp_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2147,7 +4357,7 @@ func (x *ResourceLink) String() string {
 func (*ResourceLink) ProtoMessage() {}
 
 func (x *ResourceLink) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[34]
+	mi := &file_mcp_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2160,7 +4370,7 @@ func (x *ResourceLink) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceLink.ProtoReflect.Descriptor instead.
 func (*ResourceLink) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{34}
+	return file_mcp_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *ResourceLink) GetType() string {
@@ -2193,7 +4403,7 @@ type EmbeddedResource struct {
 
 func (x *EmbeddedResource) Reset() {
 	*x = EmbeddedResource{}
-	mi := &file_mcp_proto_msgTypes[35]
+	mi := &file_mcp_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2205,7 +4415,7 @@ func (x *EmbeddedResource) String() string {
 func (*EmbeddedResource) ProtoMessage() {}
 
 func (x *EmbeddedResource) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[35]
+	mi := &file_mcp_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2218,7 +4428,7 @@ func (x *EmbeddedResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmbeddedResource.ProtoReflect.Descriptor instead.
 func (*EmbeddedResource) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{35}
+	return file_mcp_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *EmbeddedResource) GetType() string {
@@ -2299,7 +4509,7 @@ type Resource struct {
 
 func (x *Resource) Reset() {
 	*x = Resource{}
-	mi := &file_mcp_proto_msgTypes[36]
+	mi := &file_mcp_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2311,7 +4521,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[36]
+	mi := &file_mcp_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2324,7 +4534,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{36}
+	return file_mcp_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *Resource) GetName() string {
@@ -2398,7 +4608,7 @@ type ResourceTemplate struct {
 
 func (x *ResourceTemplate) Reset() {
 	*x = ResourceTemplate{}
-	mi := &file_mcp_proto_msgTypes[37]
+	mi := &file_mcp_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2410,7 +4620,7 @@ func (x *ResourceTemplate) String() string {
 func (*ResourceTemplate) ProtoMessage() {}
 
 func (x *ResourceTemplate) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[37]
+	mi := &file_mcp_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2423,7 +4633,7 @@ func (x *ResourceTemplate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceTemplate.ProtoReflect.Descriptor instead.
 func (*ResourceTemplate) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{37}
+	return file_mcp_proto_rawDescGZIP(), []int{71}
 }
 
 func (x *ResourceTemplate) GetName() string {
@@ -2475,6 +4685,88 @@ func (x *ResourceTemplate) GetXMeta() *structpb.Struct {
 	return nil
 }
 
+type ResourceContents struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to ContentsType:
+	//
+	//	*ResourceContents_Text
+	//	*ResourceContents_Blob
+	ContentsType  isResourceContents_ContentsType `protobuf_oneof:"contents_type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceContents) Reset() {
+	*x = ResourceContents{}
+	mi := &file_mcp_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceContents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceContents) ProtoMessage() {}
+
+func (x *ResourceContents) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceContents.ProtoReflect.Descriptor instead.
+func (*ResourceContents) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ResourceContents) GetContentsType() isResourceContents_ContentsType {
+	if x != nil {
+		return x.ContentsType
+	}
+	return nil
+}
+
+func (x *ResourceContents) GetText() *TextResourceContents {
+	if x != nil {
+		if x, ok := x.ContentsType.(*ResourceContents_Text); ok {
+			return x.Text
+		}
+	}
+	return nil
+}
+
+func (x *ResourceContents) GetBlob() *BlobResourceContents {
+	if x != nil {
+		if x, ok := x.ContentsType.(*ResourceContents_Blob); ok {
+			return x.Blob
+		}
+	}
+	return nil
+}
+
+type isResourceContents_ContentsType interface {
+	isResourceContents_ContentsType()
+}
+
+type ResourceContents_Text struct {
+	Text *TextResourceContents `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type ResourceContents_Blob struct {
+	Blob *BlobResourceContents `protobuf:"bytes,2,opt,name=blob,proto3,oneof"`
+}
+
+func (*ResourceContents_Text) isResourceContents_ContentsType() {}
+
+func (*ResourceContents_Blob) isResourceContents_ContentsType() {}
+
 type TextResourceContents struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uri           string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
@@ -2487,7 +4779,7 @@ type TextResourceContents struct {
 
 func (x *TextResourceContents) Reset() {
 	*x = TextResourceContents{}
-	mi := &file_mcp_proto_msgTypes[38]
+	mi := &file_mcp_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2499,7 +4791,7 @@ func (x *TextResourceContents) String() string {
 func (*TextResourceContents) ProtoMessage() {}
 
 func (x *TextResourceContents) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[38]
+	mi := &file_mcp_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2512,7 +4804,7 @@ func (x *TextResourceContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TextResourceContents.ProtoReflect.Descriptor instead.
 func (*TextResourceContents) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{38}
+	return file_mcp_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *TextResourceContents) GetUri() string {
@@ -2555,7 +4847,7 @@ type BlobResourceContents struct {
 
 func (x *BlobResourceContents) Reset() {
 	*x = BlobResourceContents{}
-	mi := &file_mcp_proto_msgTypes[39]
+	mi := &file_mcp_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2567,7 +4859,7 @@ func (x *BlobResourceContents) String() string {
 func (*BlobResourceContents) ProtoMessage() {}
 
 func (x *BlobResourceContents) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[39]
+	mi := &file_mcp_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2580,7 +4872,7 @@ func (x *BlobResourceContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobResourceContents.ProtoReflect.Descriptor instead.
 func (*BlobResourceContents) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{39}
+	return file_mcp_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *BlobResourceContents) GetUri() string {
@@ -2622,7 +4914,7 @@ type Annotations struct {
 
 func (x *Annotations) Reset() {
 	*x = Annotations{}
-	mi := &file_mcp_proto_msgTypes[40]
+	mi := &file_mcp_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2634,7 +4926,7 @@ func (x *Annotations) String() string {
 func (*Annotations) ProtoMessage() {}
 
 func (x *Annotations) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[40]
+	mi := &file_mcp_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2647,7 +4939,7 @@ func (x *Annotations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Annotations.ProtoReflect.Descriptor instead.
 func (*Annotations) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{40}
+	return file_mcp_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *Annotations) GetAudience() []Role {
@@ -2684,7 +4976,7 @@ type Reference struct {
 
 func (x *Reference) Reset() {
 	*x = Reference{}
-	mi := &file_mcp_proto_msgTypes[41]
+	mi := &file_mcp_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2696,7 +4988,7 @@ func (x *Reference) String() string {
 func (*Reference) ProtoMessage() {}
 
 func (x *Reference) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[41]
+	mi := &file_mcp_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2709,7 +5001,7 @@ func (x *Reference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Reference.ProtoReflect.Descriptor instead.
 func (*Reference) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{41}
+	return file_mcp_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *Reference) GetRefOneof() isReference_RefOneof {
@@ -2763,7 +5055,7 @@ type PromptReference struct {
 
 func (x *PromptReference) Reset() {
 	*x = PromptReference{}
-	mi := &file_mcp_proto_msgTypes[42]
+	mi := &file_mcp_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2775,7 +5067,7 @@ func (x *PromptReference) String() string {
 func (*PromptReference) ProtoMessage() {}
 
 func (x *PromptReference) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[42]
+	mi := &file_mcp_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2788,7 +5080,7 @@ func (x *PromptReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PromptReference.ProtoReflect.Descriptor instead.
 func (*PromptReference) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{42}
+	return file_mcp_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *PromptReference) GetType() string {
@@ -2815,7 +5107,7 @@ type ResourceTemplateReference struct {
 
 func (x *ResourceTemplateReference) Reset() {
 	*x = ResourceTemplateReference{}
-	mi := &file_mcp_proto_msgTypes[43]
+	mi := &file_mcp_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2827,7 +5119,7 @@ func (x *ResourceTemplateReference) String() string {
 func (*ResourceTemplateReference) ProtoMessage() {}
 
 func (x *ResourceTemplateReference) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[43]
+	mi := &file_mcp_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2840,7 +5132,7 @@ func (x *ResourceTemplateReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceTemplateReference.ProtoReflect.Descriptor instead.
 func (*ResourceTemplateReference) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{43}
+	return file_mcp_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *ResourceTemplateReference) GetType() string {
@@ -2867,7 +5159,7 @@ type CompletionArgument struct {
 
 func (x *CompletionArgument) Reset() {
 	*x = CompletionArgument{}
-	mi := &file_mcp_proto_msgTypes[44]
+	mi := &file_mcp_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2879,7 +5171,7 @@ func (x *CompletionArgument) String() string {
 func (*CompletionArgument) ProtoMessage() {}
 
 func (x *CompletionArgument) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[44]
+	mi := &file_mcp_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2892,7 +5184,7 @@ func (x *CompletionArgument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompletionArgument.ProtoReflect.Descriptor instead.
 func (*CompletionArgument) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{44}
+	return file_mcp_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *CompletionArgument) GetName() string {
@@ -2918,7 +5210,7 @@ type CompletionContext struct {
 
 func (x *CompletionContext) Reset() {
 	*x = CompletionContext{}
-	mi := &file_mcp_proto_msgTypes[45]
+	mi := &file_mcp_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2930,7 +5222,7 @@ func (x *CompletionContext) String() string {
 func (*CompletionContext) ProtoMessage() {}
 
 func (x *CompletionContext) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[45]
+	mi := &file_mcp_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2943,7 +5235,7 @@ func (x *CompletionContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompletionContext.ProtoReflect.Descriptor instead.
 func (*CompletionContext) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{45}
+	return file_mcp_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *CompletionContext) GetArguments() map[string]string {
@@ -2964,7 +5256,7 @@ type Completion struct {
 
 func (x *Completion) Reset() {
 	*x = Completion{}
-	mi := &file_mcp_proto_msgTypes[46]
+	mi := &file_mcp_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2976,7 +5268,7 @@ func (x *Completion) String() string {
 func (*Completion) ProtoMessage() {}
 
 func (x *Completion) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[46]
+	mi := &file_mcp_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2989,7 +5281,7 @@ func (x *Completion) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Completion.ProtoReflect.Descriptor instead.
 func (*Completion) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{46}
+	return file_mcp_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *Completion) GetValues() []string {
@@ -3043,13 +5335,35 @@ const file_mcp_proto_rawDesc = "" +
 	"nextCursor\x88\x01\x01\x121\n" +
 	"\x05_meta\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\r\n" +
 	"\v_nextCursorB\b\n" +
-	"\x06X_meta\"\xaf\x01\n" +
+	"\x06X_meta\"{\n" +
+	"\x0eListAllRequest\x12\x1f\n" +
+	"\bmaxPages\x18\x01 \x01(\x05H\x00R\bmaxPages\x88\x01\x01\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\v\n" +
+	"\t_maxPagesB\b\n" +
+	"\x06X_meta\"d\n" +
+	"\x13ReadResourceRequest\x12\x10\n" +
+	"\x03uri\x18\x01 \x01(\tR\x03uri\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
+	"\x06X_meta\"\x84\x01\n" +
+	"\x12ReadResourceResult\x121\n" +
+	"\bcontents\x18\x01 \x03(\v2\x15.mcp.ResourceContentsR\bcontents\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
+	"\x06X_meta\"a\n" +
+	"\x10SubscribeRequest\x12\x10\n" +
+	"\x03uri\x18\x01 \x01(\tR\x03uri\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
+	"\x06X_meta\"l\n" +
+	"\x1bResourceUpdatedNotification\x12\x10\n" +
+	"\x03uri\x18\x01 \x01(\tR\x03uri\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
+	"\x06X_meta\"\xd0\x01\n" +
 	"\x11InitializeRequest\x12(\n" +
 	"\x0fprotocolVersion\x18\x01 \x01(\tR\x0fprotocolVersion\x12;\n" +
 	"\fcapabilities\x18\x02 \x01(\v2\x17.mcp.ClientCapabilitiesR\fcapabilities\x123\n" +
 	"\n" +
 	"clientInfo\x18\x03 \x01(\v2\x13.mcp.ImplementationR\n" +
-	"clientInfo\"\xe8\x01\n" +
+	"clientInfo\x12\x1f\n" +
+	"\x05roots\x18\x04 \x03(\v2\t.mcp.RootR\x05roots\"\xe8\x01\n" +
 	"\x10InitializeResult\x12(\n" +
 	"\x0fprotocolVersion\x18\x01 \x01(\tR\x0fprotocolVersion\x12;\n" +
 	"\fcapabilities\x18\x02 \x01(\v2\x17.mcp.ServerCapabilitiesR\fcapabilities\x123\n" +
@@ -3085,18 +5399,120 @@ const file_mcp_proto_rawDesc = "" +
 	"\aisError\x18\x03 \x01(\bH\x01R\aisError\x88\x01\x01B\x14\n" +
 	"\x12_structuredContentB\n" +
 	"\n" +
-	"\b_isError\"\xa0\x01\n" +
-	"\x0fCompleteRequest\x12&\n" +
-	"\x03ref\x18\x01 \x01(\v2\x14.mcp.PromptReferenceR\x03ref\x123\n" +
+	"\b_isError\"H\n" +
+	"\x14CallToolBatchRequest\x120\n" +
+	"\brequests\x18\x01 \x03(\v2\x14.mcp.CallToolRequestR\brequests\"O\n" +
+	"\x13CallToolBatchResult\x128\n" +
+	"\tresponses\x18\x01 \x03(\v2\x1a.mcp.CallToolBatchResponseR\tresponses\"z\n" +
+	"\x15CallToolBatchResponse\x12-\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.mcp.CallToolResultH\x00R\x06result\x12&\n" +
+	"\x05error\x18\x02 \x01(\v2\x0e.mcp.CallErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"9\n" +
+	"\tCallError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb8\x01\n" +
+	"\x10CallToolProgress\x127\n" +
+	"\bprogress\x18\x01 \x01(\v2\x19.mcp.ProgressNotificationH\x00R\bprogress\x123\n" +
+	"\x03log\x18\x02 \x01(\v2\x1f.mcp.LoggingMessageNotificationH\x00R\x03log\x12-\n" +
+	"\x06result\x18\x03 \x01(\v2\x13.mcp.CallToolResultH\x00R\x06resultB\a\n" +
+	"\x05event\"\xc0\x01\n" +
+	"\x14ProgressNotification\x12<\n" +
+	"\rprogressToken\x18\x01 \x01(\v2\x16.google.protobuf.ValueR\rprogressToken\x12\x1a\n" +
+	"\bprogress\x18\x02 \x01(\x01R\bprogress\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x01H\x00R\x05total\x88\x01\x01\x12\x1d\n" +
+	"\amessage\x18\x04 \x01(\tH\x01R\amessage\x88\x01\x01B\b\n" +
+	"\x06_totalB\n" +
+	"\n" +
+	"\b_message\"\x99\x01\n" +
+	"\x1aLoggingMessageNotification\x12'\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x11.mcp.LoggingLevelR\x05level\x12\x1b\n" +
+	"\x06logger\x18\x02 \x01(\tH\x00R\x06logger\x88\x01\x01\x12*\n" +
+	"\x04data\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x04dataB\t\n" +
+	"\a_logger\"w\n" +
+	"\x0fSetLevelRequest\x12'\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x11.mcp.LoggingLevelR\x05level\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
+	"\x06X_meta\"\x10\n" +
+	"\x0eSetLevelResult\"K\n" +
+	"\x11StreamLogsRequest\x12,\n" +
+	"\x05level\x18\x01 \x01(\x0e2\x11.mcp.LoggingLevelH\x00R\x05level\x88\x01\x01B\b\n" +
+	"\x06_level\"\x15\n" +
+	"\x13WatchCatalogRequest\"\xb6\x01\n" +
+	"\rCatalogChange\x12,\n" +
+	"\x05tools\x18\x01 \x01(\v2\x14.mcp.ListToolsResultH\x00R\x05tools\x122\n" +
+	"\aprompts\x18\x02 \x01(\v2\x16.mcp.ListPromptsResultH\x00R\aprompts\x128\n" +
+	"\tresources\x18\x03 \x01(\v2\x18.mcp.ListResourcesResultH\x00R\tresourcesB\t\n" +
+	"\acatalog\"\xa9\x01\n" +
+	"\rServerRequest\x12\x1c\n" +
+	"\trequestId\x18\x01 \x01(\tR\trequestId\x12A\n" +
+	"\rcreateMessage\x18\x02 \x01(\v2\x19.mcp.CreateMessageRequestH\x00R\rcreateMessage\x12,\n" +
+	"\x06elicit\x18\x03 \x01(\v2\x12.mcp.ElicitRequestH\x00R\x06elicitB\t\n" +
+	"\arequest\"\xd3\x01\n" +
+	"\x0eClientResponse\x12\x1c\n" +
+	"\trequestId\x18\x01 \x01(\tR\trequestId\x12@\n" +
+	"\rcreateMessage\x18\x02 \x01(\v2\x18.mcp.CreateMessageResultH\x00R\rcreateMessage\x12(\n" +
+	"\x05error\x18\x03 \x01(\v2\x10.mcp.ClientErrorH\x00R\x05error\x12+\n" +
+	"\x06elicit\x18\x04 \x01(\v2\x11.mcp.ElicitResultH\x00R\x06elicitB\n" +
+	"\n" +
+	"\bresponse\";\n" +
+	"\vClientError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9e\x04\n" +
+	"\x14CreateMessageRequest\x120\n" +
+	"\bmessages\x18\x01 \x03(\v2\x14.mcp.SamplingMessageR\bmessages\x12F\n" +
+	"\x10modelPreferences\x18\x02 \x01(\v2\x15.mcp.ModelPreferencesH\x00R\x10modelPreferences\x88\x01\x01\x12'\n" +
+	"\fsystemPrompt\x18\x03 \x01(\tH\x01R\fsystemPrompt\x88\x01\x01\x12+\n" +
+	"\x0eincludeContext\x18\x04 \x01(\tH\x02R\x0eincludeContext\x88\x01\x01\x12%\n" +
+	"\vtemperature\x18\x05 \x01(\x01H\x03R\vtemperature\x88\x01\x01\x12\x1c\n" +
+	"\tmaxTokens\x18\x06 \x01(\x03R\tmaxTokens\x12$\n" +
+	"\rstopSequences\x18\a \x03(\tR\rstopSequences\x128\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructH\x04R\bmetadata\x88\x01\x01\x121\n" +
+	"\x05_meta\x18\t \x01(\v2\x17.google.protobuf.StructH\x05R\x04Meta\x88\x01\x01B\x13\n" +
+	"\x11_modelPreferencesB\x0f\n" +
+	"\r_systemPromptB\x11\n" +
+	"\x0f_includeContextB\x0e\n" +
+	"\f_temperatureB\v\n" +
+	"\t_metadataB\b\n" +
+	"\x06X_meta\"\xe8\x01\n" +
+	"\x13CreateMessageResult\x12\x1d\n" +
+	"\x04role\x18\x01 \x01(\x0e2\t.mcp.RoleR\x04role\x12+\n" +
+	"\acontent\x18\x02 \x01(\v2\x11.mcp.ContentBlockR\acontent\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12#\n" +
+	"\n" +
+	"stopReason\x18\x04 \x01(\tH\x00R\n" +
+	"stopReason\x88\x01\x01\x121\n" +
+	"\x05_meta\x18\x05 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\r\n" +
+	"\v_stopReasonB\b\n" +
+	"\x06X_meta\"\xa9\x01\n" +
+	"\rElicitRequest\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12A\n" +
+	"\x0frequestedSchema\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x0frequestedSchema\x121\n" +
+	"\x05_meta\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
+	"\x06X_meta\"\xba\x01\n" +
+	"\fElicitResult\x12)\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x11.mcp.ElicitActionR\x06action\x126\n" +
+	"\acontent\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\acontent\x88\x01\x01\x121\n" +
+	"\x05_meta\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\n" +
+	"\n" +
+	"\b_contentB\b\n" +
+	"\x06X_meta\"\xa0\x01\n" +
+	"\x0fCompleteRequest\x12 \n" +
+	"\x03ref\x18\x04 \x01(\v2\x0e.mcp.ReferenceR\x03ref\x123\n" +
 	"\bargument\x18\x02 \x01(\v2\x17.mcp.CompletionArgumentR\bargument\x120\n" +
-	"\acontext\x18\x03 \x01(\v2\x16.mcp.CompletionContextR\acontext\"A\n" +
+	"\acontext\x18\x03 \x01(\v2\x16.mcp.CompletionContextR\acontextJ\x04\b\x01\x10\x02\"A\n" +
 	"\x0eCompleteResult\x12/\n" +
 	"\n" +
 	"completion\x18\x01 \x01(\v2\x0f.mcp.CompletionR\n" +
-	"completion\"\r\n" +
+	"completion\"2\n" +
+	"\x0fSetRootsRequest\x12\x1f\n" +
+	"\x05roots\x18\x01 \x03(\v2\t.mcp.RootR\x05roots\"\x10\n" +
+	"\x0eSetRootsResult\"\r\n" +
 	"\vPingRequest\"\f\n" +
 	"\n" +
-	"PingResult\"y\n" +
+	"PingResult\"\x12\n" +
+	"\x10TerminateRequest\"\x11\n" +
+	"\x0fTerminateResult\"y\n" +
 	"\x12ListPromptsRequest\x12\x1b\n" +
 	"\x06cursor\x18\x01 \x01(\tH\x00R\x06cursor\x88\x01\x01\x121\n" +
 	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\t\n" +
@@ -3109,26 +5525,60 @@ const file_mcp_proto_rawDesc = "" +
 	"nextCursor\x88\x01\x01\x121\n" +
 	"\x05_meta\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\r\n" +
 	"\v_nextCursorB\b\n" +
-	"\x06X_meta\"c\n" +
+	"\x06X_meta\"\xe5\x01\n" +
 	"\x10GetPromptRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x121\n" +
-	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
-	"\x06X_meta\"s\n" +
-	"\x0fGetPromptResult\x12#\n" +
-	"\x06prompt\x18\x01 \x01(\v2\v.mcp.PromptR\x06prompt\x121\n" +
-	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01B\b\n" +
-	"\x06X_meta\"\xa8\x02\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01\x12B\n" +
+	"\targuments\x18\x03 \x03(\v2$.mcp.GetPromptRequest.ArgumentsEntryR\targuments\x1a<\n" +
+	"\x0eArgumentsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06X_meta\"\xc3\x01\n" +
+	"\x0fGetPromptResult\x121\n" +
+	"\x05_meta\x18\x02 \x01(\v2\x17.google.protobuf.StructH\x00R\x04Meta\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12.\n" +
+	"\bmessages\x18\x04 \x03(\v2\x12.mcp.PromptMessageR\bmessagesB\b\n" +
+	"\x06X_metaB\x0e\n" +
+	"\f_descriptionJ\x04\b\x01\x10\x02R\x06prompt\"\x92\x03\n" +
 	"\x06Prompt\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12+\n" +
 	"\acontent\x18\x03 \x03(\v2\x11.mcp.ContentBlockR\acontent\x12/\n" +
 	"\x06params\x18\x04 \x03(\v2\x17.mcp.Prompt.ParamsEntryR\x06params\x121\n" +
-	"\x05_meta\x18\x05 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01\x1aJ\n" +
+	"\x05_meta\x18\x05 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x02R\vdescription\x88\x01\x01\x121\n" +
+	"\targuments\x18\a \x03(\v2\x13.mcp.PromptArgumentR\targuments\x1aJ\n" +
 	"\vParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
 	"\x05value\x18\x02 \x01(\v2\x0f.mcp.JSONSchemaR\x05value:\x028\x01B\b\n" +
 	"\x06_titleB\b\n" +
-	"\x06X_meta\"\xd9\x02\n" +
+	"\x06X_metaB\x0e\n" +
+	"\f_description\"\xae\x01\n" +
+	"\x0ePromptArgument\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1f\n" +
+	"\brequired\x18\x04 \x01(\bH\x02R\brequired\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_required\"[\n" +
+	"\rPromptMessage\x12\x1d\n" +
+	"\x04role\x18\x01 \x01(\x0e2\t.mcp.RoleR\x04role\x12+\n" +
+	"\acontent\x18\x02 \x01(\v2\x11.mcp.ContentBlockR\acontent\"]\n" +
+	"\x0fSamplingMessage\x12\x1d\n" +
+	"\x04role\x18\x01 \x01(\x0e2\t.mcp.RoleR\x04role\x12+\n" +
+	"\acontent\x18\x02 \x01(\v2\x11.mcp.ContentBlockR\acontent\"\x81\x02\n" +
+	"\x10ModelPreferences\x12$\n" +
+	"\x05hints\x18\x01 \x03(\v2\x0e.mcp.ModelHintR\x05hints\x12'\n" +
+	"\fcostPriority\x18\x02 \x01(\x01H\x00R\fcostPriority\x88\x01\x01\x12)\n" +
+	"\rspeedPriority\x18\x03 \x01(\x01H\x01R\rspeedPriority\x88\x01\x01\x127\n" +
+	"\x14intelligencePriority\x18\x04 \x01(\x01H\x02R\x14intelligencePriority\x88\x01\x01B\x0f\n" +
+	"\r_costPriorityB\x10\n" +
+	"\x0e_speedPriorityB\x17\n" +
+	"\x15_intelligencePriority\"-\n" +
+	"\tModelHint\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01B\a\n" +
+	"\x05_name\"\xd9\x02\n" +
 	"\x12ClientCapabilities\x12M\n" +
 	"\fexperimental\x18\x01 \x03(\v2).mcp.ClientCapabilities.ExperimentalEntryR\fexperimental\x12*\n" +
 	"\x05roots\x18\x02 \x01(\v2\x14.mcp.RootsCapabilityR\x05roots\x123\n" +
@@ -3146,7 +5596,13 @@ const file_mcp_proto_rawDesc = "" +
 	"\x05tools\x18\x06 \x01(\v2\x14.mcp.ToolsCapabilityR\x05tools\x1aX\n" +
 	"\x11ExperimentalEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
-	"\x05value\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05value:\x028\x01\"H\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05value:\x028\x01\"w\n" +
+	"\x04Root\x12\x10\n" +
+	"\x03uri\x18\x01 \x01(\tR\x03uri\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x121\n" +
+	"\x05_meta\x18\x03 \x01(\v2\x17.google.protobuf.StructH\x01R\x04Meta\x88\x01\x01B\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06X_meta\"H\n" +
 	"\x0fRootsCapability\x12%\n" +
 	"\vlistChanged\x18\x01 \x01(\bH\x00R\vlistChanged\x88\x01\x01B\x0e\n" +
 	"\f_listChanged\"J\n" +
@@ -3271,7 +5727,11 @@ const file_mcp_proto_rawDesc = "" +
 	"\f_descriptionB\v\n" +
 	"\t_mimeTypeB\x0e\n" +
 	"\f_annotationsB\b\n" +
-	"\x06X_meta\"\xa7\x01\n" +
+	"\x06X_meta\"\x85\x01\n" +
+	"\x10ResourceContents\x12/\n" +
+	"\x04text\x18\x01 \x01(\v2\x19.mcp.TextResourceContentsH\x00R\x04text\x12/\n" +
+	"\x04blob\x18\x02 \x01(\v2\x19.mcp.BlobResourceContentsH\x00R\x04blobB\x0f\n" +
+	"\rcontents_type\"\xa7\x01\n" +
 	"\x14TextResourceContents\x12\x10\n" +
 	"\x03uri\x18\x01 \x01(\tR\x03uri\x12\x1f\n" +
 	"\bmimeType\x18\x02 \x01(\tH\x00R\bmimeType\x88\x01\x01\x12\x12\n" +
@@ -3321,20 +5781,53 @@ const file_mcp_proto_rawDesc = "" +
 	"\x04Role\x12\x14\n" +
 	"\x10ROLE_UNSPECIFIED\x10\x00\x12\b\n" +
 	"\x04USER\x10\x01\x12\r\n" +
-	"\tASSISTANT\x10\x022\x89\x05\n" +
+	"\tASSISTANT\x10\x02*R\n" +
+	"\fElicitAction\x12\x1d\n" +
+	"\x19ELICIT_ACTION_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06ACCEPT\x10\x01\x12\v\n" +
+	"\aDECLINE\x10\x02\x12\n" +
+	"\n" +
+	"\x06CANCEL\x10\x03*\x8e\x01\n" +
+	"\fLoggingLevel\x12\x1d\n" +
+	"\x19LOGGING_LEVEL_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05DEBUG\x10\x01\x12\b\n" +
+	"\x04INFO\x10\x02\x12\n" +
+	"\n" +
+	"\x06NOTICE\x10\x03\x12\v\n" +
+	"\aWARNING\x10\x04\x12\t\n" +
+	"\x05ERROR\x10\x05\x12\f\n" +
+	"\bCRITICAL\x10\x06\x12\t\n" +
+	"\x05ALERT\x10\a\x12\r\n" +
+	"\tEMERGENCY\x10\b2\x87\f\n" +
 	"\x14ModelContextProtocol\x12;\n" +
 	"\n" +
 	"Initialize\x12\x16.mcp.InitializeRequest\x1a\x15.mcp.InitializeResult\x127\n" +
 	"\n" +
 	"CallMethod\x12\x14.mcp.CallToolRequest\x1a\x13.mcp.CallToolResult\x12A\n" +
-	"\x10CallMethodStream\x12\x14.mcp.CallToolRequest\x1a\x13.mcp.CallToolResult(\x010\x01\x128\n" +
+	"\x10CallMethodStream\x12\x14.mcp.CallToolRequest\x1a\x13.mcp.CallToolResult(\x010\x01\x12F\n" +
+	"\x0fCallMethodBatch\x12\x19.mcp.CallToolBatchRequest\x1a\x18.mcp.CallToolBatchResult\x12E\n" +
+	"\x14CallToolWithProgress\x12\x14.mcp.CallToolRequest\x1a\x15.mcp.CallToolProgress0\x01\x128\n" +
 	"\tListTools\x12\x15.mcp.ListToolsRequest\x1a\x14.mcp.ListToolsResult\x12>\n" +
 	"\vListPrompts\x12\x17.mcp.ListPromptsRequest\x1a\x16.mcp.ListPromptsResult\x128\n" +
 	"\tGetPrompt\x12\x15.mcp.GetPromptRequest\x1a\x14.mcp.GetPromptResult\x12D\n" +
 	"\rListResources\x12\x19.mcp.ListResourcesRequest\x1a\x18.mcp.ListResourcesResult\x12\\\n" +
-	"\x15ListResourceTemplates\x12!.mcp.ListResourceTemplatesRequest\x1a .mcp.ListResourceTemplatesResult\x125\n" +
-	"\bComplete\x12\x14.mcp.CompleteRequest\x1a\x13.mcp.CompleteResult\x12)\n" +
-	"\x04Ping\x12\x10.mcp.PingRequest\x1a\x0f.mcp.PingResultB\rZ\vgrpc2mcp/pbb\x06proto3"
+	"\x15ListResourceTemplates\x12!.mcp.ListResourceTemplatesRequest\x1a .mcp.ListResourceTemplatesResult\x120\n" +
+	"\fListAllTools\x12\x13.mcp.ListAllRequest\x1a\t.mcp.Tool0\x01\x124\n" +
+	"\x0eListAllPrompts\x12\x13.mcp.ListAllRequest\x1a\v.mcp.Prompt0\x01\x128\n" +
+	"\x10ListAllResources\x12\x13.mcp.ListAllRequest\x1a\r.mcp.Resource0\x01\x12H\n" +
+	"\x18ListAllResourceTemplates\x12\x13.mcp.ListAllRequest\x1a\x15.mcp.ResourceTemplate0\x01\x12A\n" +
+	"\fReadResource\x12\x18.mcp.ReadResourceRequest\x1a\x17.mcp.ReadResourceResult\x12N\n" +
+	"\x11SubscribeResource\x12\x15.mcp.SubscribeRequest\x1a .mcp.ResourceUpdatedNotification0\x01\x125\n" +
+	"\bComplete\x12\x14.mcp.CompleteRequest\x1a\x13.mcp.CompleteResult\x12<\n" +
+	"\x0fSetLoggingLevel\x12\x14.mcp.SetLevelRequest\x1a\x13.mcp.SetLevelResult\x12G\n" +
+	"\n" +
+	"StreamLogs\x12\x16.mcp.StreamLogsRequest\x1a\x1f.mcp.LoggingMessageNotification0\x01\x126\n" +
+	"\aSession\x12\x13.mcp.ClientResponse\x1a\x12.mcp.ServerRequest(\x010\x01\x12>\n" +
+	"\fWatchCatalog\x12\x18.mcp.WatchCatalogRequest\x1a\x12.mcp.CatalogChange0\x01\x125\n" +
+	"\bSetRoots\x12\x14.mcp.SetRootsRequest\x1a\x13.mcp.SetRootsResult\x12)\n" +
+	"\x04Ping\x12\x10.mcp.PingRequest\x1a\x0f.mcp.PingResult\x128\n" +
+	"\tTerminate\x12\x15.mcp.TerminateRequest\x1a\x14.mcp.TerminateResultB\rZ\vgrpc2mcp/pbb\x06proto3"
 
 var (
 	file_mcp_proto_rawDescOnce sync.Once
@@ -3348,168 +5841,285 @@ func file_mcp_proto_rawDescGZIP() []byte {
 	return file_mcp_proto_rawDescData
 }
 
-var file_mcp_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_mcp_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
+var file_mcp_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_mcp_proto_msgTypes = make([]protoimpl.MessageInfo, 89)
 var file_mcp_proto_goTypes = []any{
 	(Role)(0),                            // 0: mcp.Role
-	(*ListResourcesRequest)(nil),         // 1: mcp.ListResourcesRequest
-	(*ListResourcesResult)(nil),          // 2: mcp.ListResourcesResult
-	(*ListResourceTemplatesRequest)(nil), // 3: mcp.ListResourceTemplatesRequest
-	(*ListResourceTemplatesResult)(nil),  // 4: mcp.ListResourceTemplatesResult
-	(*InitializeRequest)(nil),            // 5: mcp.InitializeRequest
-	(*InitializeResult)(nil),             // 6: mcp.InitializeResult
-	(*ListToolsRequest)(nil),             // 7: mcp.ListToolsRequest
-	(*ListToolsResult)(nil),              // 8: mcp.ListToolsResult
-	(*CallToolRequest)(nil),              // 9: mcp.CallToolRequest
-	(*CallToolResult)(nil),               // 10: mcp.CallToolResult
-	(*CompleteRequest)(nil),              // 11: mcp.CompleteRequest
-	(*CompleteResult)(nil),               // 12: mcp.CompleteResult
-	(*PingRequest)(nil),                  // 13: mcp.PingRequest
-	(*PingResult)(nil),                   // 14: mcp.PingResult
-	(*ListPromptsRequest)(nil),           // 15: mcp.ListPromptsRequest
-	(*ListPromptsResult)(nil),            // 16: mcp.ListPromptsResult
-	(*GetPromptRequest)(nil),             // 17: mcp.GetPromptRequest
-	(*GetPromptResult)(nil),              // 18: mcp.GetPromptResult
-	(*Prompt)(nil),                       // 19: mcp.Prompt
-	(*ClientCapabilities)(nil),           // 20: mcp.ClientCapabilities
-	(*ServerCapabilities)(nil),           // 21: mcp.ServerCapabilities
-	(*RootsCapability)(nil),              // 22: mcp.RootsCapability
-	(*PromptsCapability)(nil),            // 23: mcp.PromptsCapability
-	(*ResourcesCapability)(nil),          // 24: mcp.ResourcesCapability
-	(*ToolsCapability)(nil),              // 25: mcp.ToolsCapability
-	(*Implementation)(nil),               // 26: mcp.Implementation
-	(*BaseMetadata)(nil),                 // 27: mcp.BaseMetadata
-	(*Tool)(nil),                         // 28: mcp.Tool
-	(*JSONSchema)(nil),                   // 29: mcp.JSONSchema
-	(*ToolAnnotations)(nil),              // 30: mcp.ToolAnnotations
-	(*ContentBlock)(nil),                 // 31: mcp.ContentBlock
-	(*TextContent)(nil),                  // 32: mcp.TextContent
-	(*ImageContent)(nil),                 // 33: mcp.ImageContent
-	(*AudioContent)(nil),                 // 34: mcp.AudioContent
-	(*ResourceLink)(nil),                 // 35: mcp.ResourceLink
-	(*EmbeddedResource)(nil),             // 36: mcp.EmbeddedResource
-	(*Resource)(nil),                     // 37: mcp.Resource
-	(*ResourceTemplate)(nil),             // 38: mcp.ResourceTemplate
-	(*TextResourceContents)(nil),         // 39: mcp.TextResourceContents
-	(*BlobResourceContents)(nil),         // 40: mcp.BlobResourceContents
-	(*Annotations)(nil),                  // 41: mcp.Annotations
-	(*Reference)(nil),                    // 42: mcp.Reference
-	(*PromptReference)(nil),              // 43: mcp.PromptReference
-	(*ResourceTemplateReference)(nil),    // 44: mcp.ResourceTemplateReference
-	(*CompletionArgument)(nil),           // 45: mcp.CompletionArgument
-	(*CompletionContext)(nil),            // 46: mcp.CompletionContext
-	(*Completion)(nil),                   // 47: mcp.Completion
-	nil,                                  // 48: mcp.CallToolRequest.ArgumentsEntry
-	nil,                                  // 49: mcp.Prompt.ParamsEntry
-	nil,                                  // 50: mcp.ClientCapabilities.ExperimentalEntry
-	nil,                                  // 51: mcp.ServerCapabilities.ExperimentalEntry
-	nil,                                  // 52: mcp.JSONSchema.PropertiesEntry
-	nil,                                  // 53: mcp.CompletionContext.ArgumentsEntry
-	(*structpb.Struct)(nil),              // 54: google.protobuf.Struct
-	(*structpb.Value)(nil),               // 55: google.protobuf.Value
+	(ElicitAction)(0),                    // 1: mcp.ElicitAction
+	(LoggingLevel)(0),                    // 2: mcp.LoggingLevel
+	(*ListResourcesRequest)(nil),         // 3: mcp.ListResourcesRequest
+	(*ListResourcesResult)(nil),          // 4: mcp.ListResourcesResult
+	(*ListResourceTemplatesRequest)(nil), // 5: mcp.ListResourceTemplatesRequest
+	(*ListResourceTemplatesResult)(nil),  // 6: mcp.ListResourceTemplatesResult
+	(*ListAllRequest)(nil),               // 7: mcp.ListAllRequest
+	(*ReadResourceRequest)(nil),          // 8: mcp.ReadResourceRequest
+	(*ReadResourceResult)(nil),           // 9: mcp.ReadResourceResult
+	(*SubscribeRequest)(nil),             // 10: mcp.SubscribeRequest
+	(*ResourceUpdatedNotification)(nil),  // 11: mcp.ResourceUpdatedNotification
+	(*InitializeRequest)(nil),            // 12: mcp.InitializeRequest
+	(*InitializeResult)(nil),             // 13: mcp.InitializeResult
+	(*ListToolsRequest)(nil),             // 14: mcp.ListToolsRequest
+	(*ListToolsResult)(nil),              // 15: mcp.ListToolsResult
+	(*CallToolRequest)(nil),              // 16: mcp.CallToolRequest
+	(*CallToolResult)(nil),               // 17: mcp.CallToolResult
+	(*CallToolBatchRequest)(nil),         // 18: mcp.CallToolBatchRequest
+	(*CallToolBatchResult)(nil),          // 19: mcp.CallToolBatchResult
+	(*CallToolBatchResponse)(nil),        // 20: mcp.CallToolBatchResponse
+	(*CallError)(nil),                    // 21: mcp.CallError
+	(*CallToolProgress)(nil),             // 22: mcp.CallToolProgress
+	(*ProgressNotification)(nil),         // 23: mcp.ProgressNotification
+	(*LoggingMessageNotification)(nil),   // 24: mcp.LoggingMessageNotification
+	(*SetLevelRequest)(nil),              // 25: mcp.SetLevelRequest
+	(*SetLevelResult)(nil),               // 26: mcp.SetLevelResult
+	(*StreamLogsRequest)(nil),            // 27: mcp.StreamLogsRequest
+	(*WatchCatalogRequest)(nil),          // 28: mcp.WatchCatalogRequest
+	(*CatalogChange)(nil),                // 29: mcp.CatalogChange
+	(*ServerRequest)(nil),                // 30: mcp.ServerRequest
+	(*ClientResponse)(nil),               // 31: mcp.ClientResponse
+	(*ClientError)(nil),                  // 32: mcp.ClientError
+	(*CreateMessageRequest)(nil),         // 33: mcp.CreateMessageRequest
+	(*CreateMessageResult)(nil),          // 34: mcp.CreateMessageResult
+	(*ElicitRequest)(nil),                // 35: mcp.ElicitRequest
+	(*ElicitResult)(nil),                 // 36: mcp.ElicitResult
+	(*CompleteRequest)(nil),              // 37: mcp.CompleteRequest
+	(*CompleteResult)(nil),               // 38: mcp.CompleteResult
+	(*SetRootsRequest)(nil),              // 39: mcp.SetRootsRequest
+	(*SetRootsResult)(nil),               // 40: mcp.SetRootsResult
+	(*PingRequest)(nil),                  // 41: mcp.PingRequest
+	(*PingResult)(nil),                   // 42: mcp.PingResult
+	(*TerminateRequest)(nil),             // 43: mcp.TerminateRequest
+	(*TerminateResult)(nil),              // 44: mcp.TerminateResult
+	(*ListPromptsRequest)(nil),           // 45: mcp.ListPromptsRequest
+	(*ListPromptsResult)(nil),            // 46: mcp.ListPromptsResult
+	(*GetPromptRequest)(nil),             // 47: mcp.GetPromptRequest
+	(*GetPromptResult)(nil),              // 48: mcp.GetPromptResult
+	(*Prompt)(nil),                       // 49: mcp.Prompt
+	(*PromptArgument)(nil),               // 50: mcp.PromptArgument
+	(*PromptMessage)(nil),                // 51: mcp.PromptMessage
+	(*SamplingMessage)(nil),              // 52: mcp.SamplingMessage
+	(*ModelPreferences)(nil),             // 53: mcp.ModelPreferences
+	(*ModelHint)(nil),                    // 54: mcp.ModelHint
+	(*ClientCapabilities)(nil),           // 55: mcp.ClientCapabilities
+	(*ServerCapabilities)(nil),           // 56: mcp.ServerCapabilities
+	(*Root)(nil),                         // 57: mcp.Root
+	(*RootsCapability)(nil),              // 58: mcp.RootsCapability
+	(*PromptsCapability)(nil),            // 59: mcp.PromptsCapability
+	(*ResourcesCapability)(nil),          // 60: mcp.ResourcesCapability
+	(*ToolsCapability)(nil),              // 61: mcp.ToolsCapability
+	(*Implementation)(nil),               // 62: mcp.Implementation
+	(*BaseMetadata)(nil),                 // 63: mcp.BaseMetadata
+	(*Tool)(nil),                         // 64: mcp.Tool
+	(*JSONSchema)(nil),                   // 65: mcp.JSONSchema
+	(*ToolAnnotations)(nil),              // 66: mcp.ToolAnnotations
+	(*ContentBlock)(nil),                 // 67: mcp.ContentBlock
+	(*TextContent)(nil),                  // 68: mcp.TextContent
+	(*ImageContent)(nil),                 // 69: mcp.ImageContent
+	(*AudioContent)(nil),                 // 70: mcp.AudioContent
+	(*ResourceLink)(nil),                 // 71: mcp.ResourceLink
+	(*EmbeddedResource)(nil),             // 72: mcp.EmbeddedResource
+	(*Resource)(nil),                     // 73: mcp.Resource
+	(*ResourceTemplate)(nil),             // 74: mcp.ResourceTemplate
+	(*ResourceContents)(nil),             // 75: mcp.ResourceContents
+	(*TextResourceContents)(nil),         // 76: mcp.TextResourceContents
+	(*BlobResourceContents)(nil),         // 77: mcp.BlobResourceContents
+	(*Annotations)(nil),                  // 78: mcp.Annotations
+	(*Reference)(nil),                    // 79: mcp.Reference
+	(*PromptReference)(nil),              // 80: mcp.PromptReference
+	(*ResourceTemplateReference)(nil),    // 81: mcp.ResourceTemplateReference
+	(*CompletionArgument)(nil),           // 82: mcp.CompletionArgument
+	(*CompletionContext)(nil),            // 83: mcp.CompletionContext
+	(*Completion)(nil),                   // 84: mcp.Completion
+	nil,                                  // 85: mcp.CallToolRequest.ArgumentsEntry
+	nil,                                  // 86: mcp.GetPromptRequest.ArgumentsEntry
+	nil,                                  // 87: mcp.Prompt.ParamsEntry
+	nil,                                  // 88: mcp.ClientCapabilities.ExperimentalEntry
+	nil,                                  // 89: mcp.ServerCapabilities.ExperimentalEntry
+	nil,                                  // 90: mcp.JSONSchema.PropertiesEntry
+	nil,                                  // 91: mcp.CompletionContext.ArgumentsEntry
+	(*structpb.Struct)(nil),              // 92: google.protobuf.Struct
+	(*structpb.Value)(nil),               // 93: google.protobuf.Value
 }
 var file_mcp_proto_depIdxs = []int32{
-	54, // 0: mcp.ListResourcesRequest._meta:type_name -> google.protobuf.Struct
-	37, // 1: mcp.ListResourcesResult.resources:type_name -> mcp.Resource
-	54, // 2: mcp.ListResourcesResult._meta:type_name -> google.protobuf.Struct
-	54, // 3: mcp.ListResourceTemplatesRequest._meta:type_name -> google.protobuf.Struct
-	38, // 4: mcp.ListResourceTemplatesResult.resourceTemplates:type_name -> mcp.ResourceTemplate
-	54, // 5: mcp.ListResourceTemplatesResult._meta:type_name -> google.protobuf.Struct
-	20, // 6: mcp.InitializeRequest.capabilities:type_name -> mcp.ClientCapabilities
-	26, // 7: mcp.InitializeRequest.clientInfo:type_name -> mcp.Implementation
-	21, // 8: mcp.InitializeResult.capabilities:type_name -> mcp.ServerCapabilities
-	26, // 9: mcp.InitializeResult.serverInfo:type_name -> mcp.Implementation
-	54, // 10: mcp.ListToolsRequest._meta:type_name -> google.protobuf.Struct
-	28, // 11: mcp.ListToolsResult.tools:type_name -> mcp.Tool
-	54, // 12: mcp.ListToolsResult._meta:type_name -> google.protobuf.Struct
-	48, // 13: mcp.CallToolRequest.arguments:type_name -> mcp.CallToolRequest.ArgumentsEntry
-	54, // 14: mcp.CallToolRequest._meta:type_name -> google.protobuf.Struct
-	31, // 15: mcp.CallToolResult.content:type_name -> mcp.ContentBlock
-	54, // 16: mcp.CallToolResult.structuredContent:type_name -> google.protobuf.Struct
-	43, // 17: mcp.CompleteRequest.ref:type_name -> mcp.PromptReference
-	45, // 18: mcp.CompleteRequest.argument:type_name -> mcp.CompletionArgument
-	46, // 19: mcp.CompleteRequest.context:type_name -> mcp.CompletionContext
-	47, // 20: mcp.CompleteResult.completion:type_name -> mcp.Completion
-	54, // 21: mcp.ListPromptsRequest._meta:type_name -> google.protobuf.Struct
-	19, // 22: mcp.ListPromptsResult.prompts:type_name -> mcp.Prompt
-	54, // 23: mcp.ListPromptsResult._meta:type_name -> google.protobuf.Struct
-	54, // 24: mcp.GetPromptRequest._meta:type_name -> google.protobuf.Struct
-	19, // 25: mcp.GetPromptResult.prompt:type_name -> mcp.Prompt
-	54, // 26: mcp.GetPromptResult._meta:type_name -> google.protobuf.Struct
-	31, // 27: mcp.Prompt.content:type_name -> mcp.ContentBlock
-	49, // 28: mcp.Prompt.params:type_name -> mcp.Prompt.ParamsEntry
-	54, // 29: mcp.Prompt._meta:type_name -> google.protobuf.Struct
-	50, // 30: mcp.ClientCapabilities.experimental:type_name -> mcp.ClientCapabilities.ExperimentalEntry
-	22, // 31: mcp.ClientCapabilities.roots:type_name -> mcp.RootsCapability
-	54, // 32: mcp.ClientCapabilities.sampling:type_name -> google.protobuf.Struct
-	54, // 33: mcp.ClientCapabilities.elicitation:type_name -> google.protobuf.Struct
-	51, // 34: mcp.ServerCapabilities.experimental:type_name -> mcp.ServerCapabilities.ExperimentalEntry
-	54, // 35: mcp.ServerCapabilities.logging:type_name -> google.protobuf.Struct
-	54, // 36: mcp.ServerCapabilities.completions:type_name -> google.protobuf.Struct
-	23, // 37: mcp.ServerCapabilities.prompts:type_name -> mcp.PromptsCapability
-	24, // 38: mcp.ServerCapabilities.resources:type_name -> mcp.ResourcesCapability
-	25, // 39: mcp.ServerCapabilities.tools:type_name -> mcp.ToolsCapability
-	29, // 40: mcp.Tool.inputSchema:type_name -> mcp.JSONSchema
-	29, // 41: mcp.Tool.outputSchema:type_name -> mcp.JSONSchema
-	30, // 42: mcp.Tool.annotations:type_name -> mcp.ToolAnnotations
-	54, // 43: mcp.Tool._meta:type_name -> google.protobuf.Struct
-	52, // 44: mcp.JSONSchema.properties:type_name -> mcp.JSONSchema.PropertiesEntry
-	32, // 45: mcp.ContentBlock.text:type_name -> mcp.TextContent
-	33, // 46: mcp.ContentBlock.image:type_name -> mcp.ImageContent
-	34, // 47: mcp.ContentBlock.audio:type_name -> mcp.AudioContent
-	35, // 48: mcp.ContentBlock.resourceLink:type_name -> mcp.ResourceLink
-	36, // 49: mcp.ContentBlock.embeddedResource:type_name -> mcp.EmbeddedResource
-	41, // 50: mcp.TextContent.annotations:type_name -> mcp.Annotations
-	54, // 51: mcp.TextContent._meta:type_name -> google.protobuf.Struct
-	41, // 52: mcp.ImageContent.annotations:type_name -> mcp.Annotations
-	54, // 53: mcp.ImageContent._meta:type_name -> google.protobuf.Struct
-	41, // 54: mcp.AudioContent.annotations:type_name -> mcp.Annotations
-	54, // 55: mcp.AudioContent._meta:type_name -> google.protobuf.Struct
-	37, // 56: mcp.ResourceLink.resource:type_name -> mcp.Resource
-	39, // 57: mcp.EmbeddedResource.textResource:type_name -> mcp.TextResourceContents
-	40, // 58: mcp.EmbeddedResource.blobResource:type_name -> mcp.BlobResourceContents
-	41, // 59: mcp.EmbeddedResource.annotations:type_name -> mcp.Annotations
-	54, // 60: mcp.EmbeddedResource._meta:type_name -> google.protobuf.Struct
-	41, // 61: mcp.Resource.annotations:type_name -> mcp.Annotations
-	54, // 62: mcp.Resource._meta:type_name -> google.protobuf.Struct
-	41, // 63: mcp.ResourceTemplate.annotations:type_name -> mcp.Annotations
-	54, // 64: mcp.ResourceTemplate._meta:type_name -> google.protobuf.Struct
-	54, // 65: mcp.TextResourceContents._meta:type_name -> google.protobuf.Struct
-	54, // 66: mcp.BlobResourceContents._meta:type_name -> google.protobuf.Struct
-	0,  // 67: mcp.Annotations.audience:type_name -> mcp.Role
-	43, // 68: mcp.Reference.prompt:type_name -> mcp.PromptReference
-	44, // 69: mcp.Reference.resourceTemplate:type_name -> mcp.ResourceTemplateReference
-	53, // 70: mcp.CompletionContext.arguments:type_name -> mcp.CompletionContext.ArgumentsEntry
-	55, // 71: mcp.CallToolRequest.ArgumentsEntry.value:type_name -> google.protobuf.Value
-	29, // 72: mcp.Prompt.ParamsEntry.value:type_name -> mcp.JSONSchema
-	54, // 73: mcp.ClientCapabilities.ExperimentalEntry.value:type_name -> google.protobuf.Struct
-	54, // 74: mcp.ServerCapabilities.ExperimentalEntry.value:type_name -> google.protobuf.Struct
-	29, // 75: mcp.JSONSchema.PropertiesEntry.value:type_name -> mcp.JSONSchema
-	5,  // 76: mcp.ModelContextProtocol.Initialize:input_type -> mcp.InitializeRequest
-	9,  // 77: mcp.ModelContextProtocol.CallMethod:input_type -> mcp.CallToolRequest
-	9,  // 78: mcp.ModelContextProtocol.CallMethodStream:input_type -> mcp.CallToolRequest
-	7,  // 79: mcp.ModelContextProtocol.ListTools:input_type -> mcp.ListToolsRequest
-	15, // 80: mcp.ModelContextProtocol.ListPrompts:input_type -> mcp.ListPromptsRequest
-	17, // 81: mcp.ModelContextProtocol.GetPrompt:input_type -> mcp.GetPromptRequest
-	1,  // 82: mcp.ModelContextProtocol.ListResources:input_type -> mcp.ListResourcesRequest
-	3,  // 83: mcp.ModelContextProtocol.ListResourceTemplates:input_type -> mcp.ListResourceTemplatesRequest
-	11, // 84: mcp.ModelContextProtocol.Complete:input_type -> mcp.CompleteRequest
-	13, // 85: mcp.ModelContextProtocol.Ping:input_type -> mcp.PingRequest
-	6,  // 86: mcp.ModelContextProtocol.Initialize:output_type -> mcp.InitializeResult
-	10, // 87: mcp.ModelContextProtocol.CallMethod:output_type -> mcp.CallToolResult
-	10, // 88: mcp.ModelContextProtocol.CallMethodStream:output_type -> mcp.CallToolResult
-	8,  // 89: mcp.ModelContextProtocol.ListTools:output_type -> mcp.ListToolsResult
-	16, // 90: mcp.ModelContextProtocol.ListPrompts:output_type -> mcp.ListPromptsResult
-	18, // 91: mcp.ModelContextProtocol.GetPrompt:output_type -> mcp.GetPromptResult
-	2,  // 92: mcp.ModelContextProtocol.ListResources:output_type -> mcp.ListResourcesResult
-	4,  // 93: mcp.ModelContextProtocol.ListResourceTemplates:output_type -> mcp.ListResourceTemplatesResult
-	12, // 94: mcp.ModelContextProtocol.Complete:output_type -> mcp.CompleteResult
-	14, // 95: mcp.ModelContextProtocol.Ping:output_type -> mcp.PingResult
-	86, // [86:96] is the sub-list for method output_type
-	76, // [76:86] is the sub-list for method input_type
-	76, // [76:76] is the sub-list for extension type_name
-	76, // [76:76] is the sub-list for extension extendee
-	0,  // [0:76] is the sub-list for field type_name
+	92,  // 0: mcp.ListResourcesRequest._meta:type_name -> google.protobuf.Struct
+	73,  // 1: mcp.ListResourcesResult.resources:type_name -> mcp.Resource
+	92,  // 2: mcp.ListResourcesResult._meta:type_name -> google.protobuf.Struct
+	92,  // 3: mcp.ListResourceTemplatesRequest._meta:type_name -> google.protobuf.Struct
+	74,  // 4: mcp.ListResourceTemplatesResult.resourceTemplates:type_name -> mcp.ResourceTemplate
+	92,  // 5: mcp.ListResourceTemplatesResult._meta:type_name -> google.protobuf.Struct
+	92,  // 6: mcp.ListAllRequest._meta:type_name -> google.protobuf.Struct
+	92,  // 7: mcp.ReadResourceRequest._meta:type_name -> google.protobuf.Struct
+	75,  // 8: mcp.ReadResourceResult.contents:type_name -> mcp.ResourceContents
+	92,  // 9: mcp.ReadResourceResult._meta:type_name -> google.protobuf.Struct
+	92,  // 10: mcp.SubscribeRequest._meta:type_name -> google.protobuf.Struct
+	92,  // 11: mcp.ResourceUpdatedNotification._meta:type_name -> google.protobuf.Struct
+	55,  // 12: mcp.InitializeRequest.capabilities:type_name -> mcp.ClientCapabilities
+	62,  // 13: mcp.InitializeRequest.clientInfo:type_name -> mcp.Implementation
+	57,  // 14: mcp.InitializeRequest.roots:type_name -> mcp.Root
+	56,  // 15: mcp.InitializeResult.capabilities:type_name -> mcp.ServerCapabilities
+	62,  // 16: mcp.InitializeResult.serverInfo:type_name -> mcp.Implementation
+	92,  // 17: mcp.ListToolsRequest._meta:type_name -> google.protobuf.Struct
+	64,  // 18: mcp.ListToolsResult.tools:type_name -> mcp.Tool
+	92,  // 19: mcp.ListToolsResult._meta:type_name -> google.protobuf.Struct
+	85,  // 20: mcp.CallToolRequest.arguments:type_name -> mcp.CallToolRequest.ArgumentsEntry
+	92,  // 21: mcp.CallToolRequest._meta:type_name -> google.protobuf.Struct
+	67,  // 22: mcp.CallToolResult.content:type_name -> mcp.ContentBlock
+	92,  // 23: mcp.CallToolResult.structuredContent:type_name -> google.protobuf.Struct
+	16,  // 24: mcp.CallToolBatchRequest.requests:type_name -> mcp.CallToolRequest
+	20,  // 25: mcp.CallToolBatchResult.responses:type_name -> mcp.CallToolBatchResponse
+	17,  // 26: mcp.CallToolBatchResponse.result:type_name -> mcp.CallToolResult
+	21,  // 27: mcp.CallToolBatchResponse.error:type_name -> mcp.CallError
+	23,  // 28: mcp.CallToolProgress.progress:type_name -> mcp.ProgressNotification
+	24,  // 29: mcp.CallToolProgress.log:type_name -> mcp.LoggingMessageNotification
+	17,  // 30: mcp.CallToolProgress.result:type_name -> mcp.CallToolResult
+	93,  // 31: mcp.ProgressNotification.progressToken:type_name -> google.protobuf.Value
+	2,   // 32: mcp.LoggingMessageNotification.level:type_name -> mcp.LoggingLevel
+	93,  // 33: mcp.LoggingMessageNotification.data:type_name -> google.protobuf.Value
+	2,   // 34: mcp.SetLevelRequest.level:type_name -> mcp.LoggingLevel
+	92,  // 35: mcp.SetLevelRequest._meta:type_name -> google.protobuf.Struct
+	2,   // 36: mcp.StreamLogsRequest.level:type_name -> mcp.LoggingLevel
+	15,  // 37: mcp.CatalogChange.tools:type_name -> mcp.ListToolsResult
+	46,  // 38: mcp.CatalogChange.prompts:type_name -> mcp.ListPromptsResult
+	4,   // 39: mcp.CatalogChange.resources:type_name -> mcp.ListResourcesResult
+	33,  // 40: mcp.ServerRequest.createMessage:type_name -> mcp.CreateMessageRequest
+	35,  // 41: mcp.ServerRequest.elicit:type_name -> mcp.ElicitRequest
+	34,  // 42: mcp.ClientResponse.createMessage:type_name -> mcp.CreateMessageResult
+	32,  // 43: mcp.ClientResponse.error:type_name -> mcp.ClientError
+	36,  // 44: mcp.ClientResponse.elicit:type_name -> mcp.ElicitResult
+	52,  // 45: mcp.CreateMessageRequest.messages:type_name -> mcp.SamplingMessage
+	53,  // 46: mcp.CreateMessageRequest.modelPreferences:type_name -> mcp.ModelPreferences
+	92,  // 47: mcp.CreateMessageRequest.metadata:type_name -> google.protobuf.Struct
+	92,  // 48: mcp.CreateMessageRequest._meta:type_name -> google.protobuf.Struct
+	0,   // 49: mcp.CreateMessageResult.role:type_name -> mcp.Role
+	67,  // 50: mcp.CreateMessageResult.content:type_name -> mcp.ContentBlock
+	92,  // 51: mcp.CreateMessageResult._meta:type_name -> google.protobuf.Struct
+	92,  // 52: mcp.ElicitRequest.requestedSchema:type_name -> google.protobuf.Struct
+	92,  // 53: mcp.ElicitRequest._meta:type_name -> google.protobuf.Struct
+	1,   // 54: mcp.ElicitResult.action:type_name -> mcp.ElicitAction
+	92,  // 55: mcp.ElicitResult.content:type_name -> google.protobuf.Struct
+	92,  // 56: mcp.ElicitResult._meta:type_name -> google.protobuf.Struct
+	79,  // 57: mcp.CompleteRequest.ref:type_name -> mcp.Reference
+	82,  // 58: mcp.CompleteRequest.argument:type_name -> mcp.CompletionArgument
+	83,  // 59: mcp.CompleteRequest.context:type_name -> mcp.CompletionContext
+	84,  // 60: mcp.CompleteResult.completion:type_name -> mcp.Completion
+	57,  // 61: mcp.SetRootsRequest.roots:type_name -> mcp.Root
+	92,  // 62: mcp.ListPromptsRequest._meta:type_name -> google.protobuf.Struct
+	49,  // 63: mcp.ListPromptsResult.prompts:type_name -> mcp.Prompt
+	92,  // 64: mcp.ListPromptsResult._meta:type_name -> google.protobuf.Struct
+	92,  // 65: mcp.GetPromptRequest._meta:type_name -> google.protobuf.Struct
+	86,  // 66: mcp.GetPromptRequest.arguments:type_name -> mcp.GetPromptRequest.ArgumentsEntry
+	92,  // 67: mcp.GetPromptResult._meta:type_name -> google.protobuf.Struct
+	51,  // 68: mcp.GetPromptResult.messages:type_name -> mcp.PromptMessage
+	67,  // 69: mcp.Prompt.content:type_name -> mcp.ContentBlock
+	87,  // 70: mcp.Prompt.params:type_name -> mcp.Prompt.ParamsEntry
+	92,  // 71: mcp.Prompt._meta:type_name -> google.protobuf.Struct
+	50,  // 72: mcp.Prompt.arguments:type_name -> mcp.PromptArgument
+	0,   // 73: mcp.PromptMessage.role:type_name -> mcp.Role
+	67,  // 74: mcp.PromptMessage.content:type_name -> mcp.ContentBlock
+	0,   // 75: mcp.SamplingMessage.role:type_name -> mcp.Role
+	67,  // 76: mcp.SamplingMessage.content:type_name -> mcp.ContentBlock
+	54,  // 77: mcp.ModelPreferences.hints:type_name -> mcp.ModelHint
+	88,  // 78: mcp.ClientCapabilities.experimental:type_name -> mcp.ClientCapabilities.ExperimentalEntry
+	58,  // 79: mcp.ClientCapabilities.roots:type_name -> mcp.RootsCapability
+	92,  // 80: mcp.ClientCapabilities.sampling:type_name -> google.protobuf.Struct
+	92,  // 81: mcp.ClientCapabilities.elicitation:type_name -> google.protobuf.Struct
+	89,  // 82: mcp.ServerCapabilities.experimental:type_name -> mcp.ServerCapabilities.ExperimentalEntry
+	92,  // 83: mcp.ServerCapabilities.logging:type_name -> google.protobuf.Struct
+	92,  // 84: mcp.ServerCapabilities.completions:type_name -> google.protobuf.Struct
+	59,  // 85: mcp.ServerCapabilities.prompts:type_name -> mcp.PromptsCapability
+	60,  // 86: mcp.ServerCapabilities.resources:type_name -> mcp.ResourcesCapability
+	61,  // 87: mcp.ServerCapabilities.tools:type_name -> mcp.ToolsCapability
+	92,  // 88: mcp.Root._meta:type_name -> google.protobuf.Struct
+	65,  // 89: mcp.Tool.inputSchema:type_name -> mcp.JSONSchema
+	65,  // 90: mcp.Tool.outputSchema:type_name -> mcp.JSONSchema
+	66,  // 91: mcp.Tool.annotations:type_name -> mcp.ToolAnnotations
+	92,  // 92: mcp.Tool._meta:type_name -> google.protobuf.Struct
+	90,  // 93: mcp.JSONSchema.properties:type_name -> mcp.JSONSchema.PropertiesEntry
+	68,  // 94: mcp.ContentBlock.text:type_name -> mcp.TextContent
+	69,  // 95: mcp.ContentBlock.image:type_name -> mcp.ImageContent
+	70,  // 96: mcp.ContentBlock.audio:type_name -> mcp.AudioContent
+	71,  // 97: mcp.ContentBlock.resourceLink:type_name -> mcp.ResourceLink
+	72,  // 98: mcp.ContentBlock.embeddedResource:type_name -> mcp.EmbeddedResource
+	78,  // 99: mcp.TextContent.annotations:type_name -> mcp.Annotations
+	92,  // 100: mcp.TextContent._meta:type_name -> google.protobuf.Struct
+	78,  // 101: mcp.ImageContent.annotations:type_name -> mcp.Annotations
+	92,  // 102: mcp.ImageContent._meta:type_name -> google.protobuf.Struct
+	78,  // 103: mcp.AudioContent.annotations:type_name -> mcp.Annotations
+	92,  // 104: mcp.AudioContent._meta:type_name -> google.protobuf.Struct
+	73,  // 105: mcp.ResourceLink.resource:type_name -> mcp.Resource
+	76,  // 106: mcp.EmbeddedResource.textResource:type_name -> mcp.TextResourceContents
+	77,  // 107: mcp.EmbeddedResource.blobResource:type_name -> mcp.BlobResourceContents
+	78,  // 108: mcp.EmbeddedResource.annotations:type_name -> mcp.Annotations
+	92,  // 109: mcp.EmbeddedResource._meta:type_name -> google.protobuf.Struct
+	78,  // 110: mcp.Resource.annotations:type_name -> mcp.Annotations
+	92,  // 111: mcp.Resource._meta:type_name -> google.protobuf.Struct
+	78,  // 112: mcp.ResourceTemplate.annotations:type_name -> mcp.Annotations
+	92,  // 113: mcp.ResourceTemplate._meta:type_name -> google.protobuf.Struct
+	76,  // 114: mcp.ResourceContents.text:type_name -> mcp.TextResourceContents
+	77,  // 115: mcp.ResourceContents.blob:type_name -> mcp.BlobResourceContents
+	92,  // 116: mcp.TextResourceContents._meta:type_name -> google.protobuf.Struct
+	92,  // 117: mcp.BlobResourceContents._meta:type_name -> google.protobuf.Struct
+	0,   // 118: mcp.Annotations.audience:type_name -> mcp.Role
+	80,  // 119: mcp.Reference.prompt:type_name -> mcp.PromptReference
+	81,  // 120: mcp.Reference.resourceTemplate:type_name -> mcp.ResourceTemplateReference
+	91,  // 121: mcp.CompletionContext.arguments:type_name -> mcp.CompletionContext.ArgumentsEntry
+	93,  // 122: mcp.CallToolRequest.ArgumentsEntry.value:type_name -> google.protobuf.Value
+	65,  // 123: mcp.Prompt.ParamsEntry.value:type_name -> mcp.JSONSchema
+	92,  // 124: mcp.ClientCapabilities.ExperimentalEntry.value:type_name -> google.protobuf.Struct
+	92,  // 125: mcp.ServerCapabilities.ExperimentalEntry.value:type_name -> google.protobuf.Struct
+	65,  // 126: mcp.JSONSchema.PropertiesEntry.value:type_name -> mcp.JSONSchema
+	12,  // 127: mcp.ModelContextProtocol.Initialize:input_type -> mcp.InitializeRequest
+	16,  // 128: mcp.ModelContextProtocol.CallMethod:input_type -> mcp.CallToolRequest
+	16,  // 129: mcp.ModelContextProtocol.CallMethodStream:input_type -> mcp.CallToolRequest
+	18,  // 130: mcp.ModelContextProtocol.CallMethodBatch:input_type -> mcp.CallToolBatchRequest
+	16,  // 131: mcp.ModelContextProtocol.CallToolWithProgress:input_type -> mcp.CallToolRequest
+	14,  // 132: mcp.ModelContextProtocol.ListTools:input_type -> mcp.ListToolsRequest
+	45,  // 133: mcp.ModelContextProtocol.ListPrompts:input_type -> mcp.ListPromptsRequest
+	47,  // 134: mcp.ModelContextProtocol.GetPrompt:input_type -> mcp.GetPromptRequest
+	3,   // 135: mcp.ModelContextProtocol.ListResources:input_type -> mcp.ListResourcesRequest
+	5,   // 136: mcp.ModelContextProtocol.ListResourceTemplates:input_type -> mcp.ListResourceTemplatesRequest
+	7,   // 137: mcp.ModelContextProtocol.ListAllTools:input_type -> mcp.ListAllRequest
+	7,   // 138: mcp.ModelContextProtocol.ListAllPrompts:input_type -> mcp.ListAllRequest
+	7,   // 139: mcp.ModelContextProtocol.ListAllResources:input_type -> mcp.ListAllRequest
+	7,   // 140: mcp.ModelContextProtocol.ListAllResourceTemplates:input_type -> mcp.ListAllRequest
+	8,   // 141: mcp.ModelContextProtocol.ReadResource:input_type -> mcp.ReadResourceRequest
+	10,  // 142: mcp.ModelContextProtocol.SubscribeResource:input_type -> mcp.SubscribeRequest
+	37,  // 143: mcp.ModelContextProtocol.Complete:input_type -> mcp.CompleteRequest
+	25,  // 144: mcp.ModelContextProtocol.SetLoggingLevel:input_type -> mcp.SetLevelRequest
+	27,  // 145: mcp.ModelContextProtocol.StreamLogs:input_type -> mcp.StreamLogsRequest
+	31,  // 146: mcp.ModelContextProtocol.Session:input_type -> mcp.ClientResponse
+	28,  // 147: mcp.ModelContextProtocol.WatchCatalog:input_type -> mcp.WatchCatalogRequest
+	39,  // 148: mcp.ModelContextProtocol.SetRoots:input_type -> mcp.SetRootsRequest
+	41,  // 149: mcp.ModelContextProtocol.Ping:input_type -> mcp.PingRequest
+	43,  // 150: mcp.ModelContextProtocol.Terminate:input_type -> mcp.TerminateRequest
+	13,  // 151: mcp.ModelContextProtocol.Initialize:output_type -> mcp.InitializeResult
+	17,  // 152: mcp.ModelContextProtocol.CallMethod:output_type -> mcp.CallToolResult
+	17,  // 153: mcp.ModelContextProtocol.CallMethodStream:output_type -> mcp.CallToolResult
+	19,  // 154: mcp.ModelContextProtocol.CallMethodBatch:output_type -> mcp.CallToolBatchResult
+	22,  // 155: mcp.ModelContextProtocol.CallToolWithProgress:output_type -> mcp.CallToolProgress
+	15,  // 156: mcp.ModelContextProtocol.ListTools:output_type -> mcp.ListToolsResult
+	46,  // 157: mcp.ModelContextProtocol.ListPrompts:output_type -> mcp.ListPromptsResult
+	48,  // 158: mcp.ModelContextProtocol.GetPrompt:output_type -> mcp.GetPromptResult
+	4,   // 159: mcp.ModelContextProtocol.ListResources:output_type -> mcp.ListResourcesResult
+	6,   // 160: mcp.ModelContextProtocol.ListResourceTemplates:output_type -> mcp.ListResourceTemplatesResult
+	64,  // 161: mcp.ModelContextProtocol.ListAllTools:output_type -> mcp.Tool
+	49,  // 162: mcp.ModelContextProtocol.ListAllPrompts:output_type -> mcp.Prompt
+	73,  // 163: mcp.ModelContextProtocol.ListAllResources:output_type -> mcp.Resource
+	74,  // 164: mcp.ModelContextProtocol.ListAllResourceTemplates:output_type -> mcp.ResourceTemplate
+	9,   // 165: mcp.ModelContextProtocol.ReadResource:output_type -> mcp.ReadResourceResult
+	11,  // 166: mcp.ModelContextProtocol.SubscribeResource:output_type -> mcp.ResourceUpdatedNotification
+	38,  // 167: mcp.ModelContextProtocol.Complete:output_type -> mcp.CompleteResult
+	26,  // 168: mcp.ModelContextProtocol.SetLoggingLevel:output_type -> mcp.SetLevelResult
+	24,  // 169: mcp.ModelContextProtocol.StreamLogs:output_type -> mcp.LoggingMessageNotification
+	30,  // 170: mcp.ModelContextProtocol.Session:output_type -> mcp.ServerRequest
+	29,  // 171: mcp.ModelContextProtocol.WatchCatalog:output_type -> mcp.CatalogChange
+	40,  // 172: mcp.ModelContextProtocol.SetRoots:output_type -> mcp.SetRootsResult
+	42,  // 173: mcp.ModelContextProtocol.Ping:output_type -> mcp.PingResult
+	44,  // 174: mcp.ModelContextProtocol.Terminate:output_type -> mcp.TerminateResult
+	151, // [151:175] is the sub-list for method output_type
+	127, // [127:151] is the sub-list for method input_type
+	127, // [127:127] is the sub-list for extension type_name
+	127, // [127:127] is the sub-list for extension extendee
+	0,   // [0:127] is the sub-list for field type_name
 }
 
 func init() { file_mcp_proto_init() }
@@ -3521,55 +6131,99 @@ func file_mcp_proto_init() {
 	file_mcp_proto_msgTypes[1].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[2].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[3].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[4].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[5].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[6].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[7].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[8].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[9].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[10].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[11].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[12].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[13].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[14].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[15].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[16].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[17].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[18].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[17].OneofWrappers = []any{
+		(*CallToolBatchResponse_Result)(nil),
+		(*CallToolBatchResponse_Error)(nil),
+	}
+	file_mcp_proto_msgTypes[19].OneofWrappers = []any{
+		(*CallToolProgress_Progress)(nil),
+		(*CallToolProgress_Log)(nil),
+		(*CallToolProgress_Result)(nil),
+	}
+	file_mcp_proto_msgTypes[20].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[21].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[22].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[23].OneofWrappers = []any{}
 	file_mcp_proto_msgTypes[24].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[25].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[26].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[27].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[29].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[30].OneofWrappers = []any{
+	file_mcp_proto_msgTypes[26].OneofWrappers = []any{
+		(*CatalogChange_Tools)(nil),
+		(*CatalogChange_Prompts)(nil),
+		(*CatalogChange_Resources)(nil),
+	}
+	file_mcp_proto_msgTypes[27].OneofWrappers = []any{
+		(*ServerRequest_CreateMessage)(nil),
+		(*ServerRequest_Elicit)(nil),
+	}
+	file_mcp_proto_msgTypes[28].OneofWrappers = []any{
+		(*ClientResponse_CreateMessage)(nil),
+		(*ClientResponse_Error)(nil),
+		(*ClientResponse_Elicit)(nil),
+	}
+	file_mcp_proto_msgTypes[30].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[31].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[32].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[33].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[42].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[43].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[44].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[45].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[46].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[47].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[50].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[51].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[54].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[55].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[56].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[57].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[58].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[59].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[60].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[61].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[63].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[64].OneofWrappers = []any{
 		(*ContentBlock_Text)(nil),
 		(*ContentBlock_Image)(nil),
 		(*ContentBlock_Audio)(nil),
 		(*ContentBlock_ResourceLink)(nil),
 		(*ContentBlock_EmbeddedResource)(nil),
 	}
-	file_mcp_proto_msgTypes[31].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[32].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[33].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[35].OneofWrappers = []any{
+	file_mcp_proto_msgTypes[65].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[66].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[67].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[69].OneofWrappers = []any{
 		(*EmbeddedResource_TextResource)(nil),
 		(*EmbeddedResource_BlobResource)(nil),
 	}
-	file_mcp_proto_msgTypes[36].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[37].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[38].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[39].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[40].OneofWrappers = []any{}
-	file_mcp_proto_msgTypes[41].OneofWrappers = []any{
+	file_mcp_proto_msgTypes[70].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[71].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[72].OneofWrappers = []any{
+		(*ResourceContents_Text)(nil),
+		(*ResourceContents_Blob)(nil),
+	}
+	file_mcp_proto_msgTypes[73].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[74].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[75].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[76].OneofWrappers = []any{
 		(*Reference_Prompt)(nil),
 		(*Reference_ResourceTemplate)(nil),
 	}
-	file_mcp_proto_msgTypes[46].OneofWrappers = []any{}
+	file_mcp_proto_msgTypes[81].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mcp_proto_rawDesc), len(file_mcp_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   53,
+			NumEnums:      3,
+			NumMessages:   89,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
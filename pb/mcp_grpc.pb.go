@@ -19,16 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ModelContextProtocol_Initialize_FullMethodName            = "/mcp.ModelContextProtocol/Initialize"
-	ModelContextProtocol_CallMethod_FullMethodName            = "/mcp.ModelContextProtocol/CallMethod"
-	ModelContextProtocol_CallMethodStream_FullMethodName      = "/mcp.ModelContextProtocol/CallMethodStream"
-	ModelContextProtocol_ListTools_FullMethodName             = "/mcp.ModelContextProtocol/ListTools"
-	ModelContextProtocol_ListPrompts_FullMethodName           = "/mcp.ModelContextProtocol/ListPrompts"
-	ModelContextProtocol_GetPrompt_FullMethodName             = "/mcp.ModelContextProtocol/GetPrompt"
-	ModelContextProtocol_ListResources_FullMethodName         = "/mcp.ModelContextProtocol/ListResources"
-	ModelContextProtocol_ListResourceTemplates_FullMethodName = "/mcp.ModelContextProtocol/ListResourceTemplates"
-	ModelContextProtocol_Complete_FullMethodName              = "/mcp.ModelContextProtocol/Complete"
-	ModelContextProtocol_Ping_FullMethodName                  = "/mcp.ModelContextProtocol/Ping"
+	ModelContextProtocol_Initialize_FullMethodName               = "/mcp.ModelContextProtocol/Initialize"
+	ModelContextProtocol_CallMethod_FullMethodName               = "/mcp.ModelContextProtocol/CallMethod"
+	ModelContextProtocol_CallMethodStream_FullMethodName         = "/mcp.ModelContextProtocol/CallMethodStream"
+	ModelContextProtocol_CallMethodBatch_FullMethodName          = "/mcp.ModelContextProtocol/CallMethodBatch"
+	ModelContextProtocol_CallToolWithProgress_FullMethodName     = "/mcp.ModelContextProtocol/CallToolWithProgress"
+	ModelContextProtocol_ListTools_FullMethodName                = "/mcp.ModelContextProtocol/ListTools"
+	ModelContextProtocol_ListPrompts_FullMethodName              = "/mcp.ModelContextProtocol/ListPrompts"
+	ModelContextProtocol_GetPrompt_FullMethodName                = "/mcp.ModelContextProtocol/GetPrompt"
+	ModelContextProtocol_ListResources_FullMethodName            = "/mcp.ModelContextProtocol/ListResources"
+	ModelContextProtocol_ListResourceTemplates_FullMethodName    = "/mcp.ModelContextProtocol/ListResourceTemplates"
+	ModelContextProtocol_ListAllTools_FullMethodName             = "/mcp.ModelContextProtocol/ListAllTools"
+	ModelContextProtocol_ListAllPrompts_FullMethodName           = "/mcp.ModelContextProtocol/ListAllPrompts"
+	ModelContextProtocol_ListAllResources_FullMethodName         = "/mcp.ModelContextProtocol/ListAllResources"
+	ModelContextProtocol_ListAllResourceTemplates_FullMethodName = "/mcp.ModelContextProtocol/ListAllResourceTemplates"
+	ModelContextProtocol_ReadResource_FullMethodName             = "/mcp.ModelContextProtocol/ReadResource"
+	ModelContextProtocol_SubscribeResource_FullMethodName        = "/mcp.ModelContextProtocol/SubscribeResource"
+	ModelContextProtocol_Complete_FullMethodName                 = "/mcp.ModelContextProtocol/Complete"
+	ModelContextProtocol_SetLoggingLevel_FullMethodName          = "/mcp.ModelContextProtocol/SetLoggingLevel"
+	ModelContextProtocol_StreamLogs_FullMethodName               = "/mcp.ModelContextProtocol/StreamLogs"
+	ModelContextProtocol_Session_FullMethodName                  = "/mcp.ModelContextProtocol/Session"
+	ModelContextProtocol_WatchCatalog_FullMethodName             = "/mcp.ModelContextProtocol/WatchCatalog"
+	ModelContextProtocol_SetRoots_FullMethodName                 = "/mcp.ModelContextProtocol/SetRoots"
+	ModelContextProtocol_Ping_FullMethodName                     = "/mcp.ModelContextProtocol/Ping"
+	ModelContextProtocol_Terminate_FullMethodName                = "/mcp.ModelContextProtocol/Terminate"
 )
 
 // ModelContextProtocolClient is the client API for ModelContextProtocol service.
@@ -38,13 +52,27 @@ type ModelContextProtocolClient interface {
 	Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResult, error)
 	CallMethod(ctx context.Context, in *CallToolRequest, opts ...grpc.CallOption) (*CallToolResult, error)
 	CallMethodStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CallToolRequest, CallToolResult], error)
+	CallMethodBatch(ctx context.Context, in *CallToolBatchRequest, opts ...grpc.CallOption) (*CallToolBatchResult, error)
+	CallToolWithProgress(ctx context.Context, in *CallToolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CallToolProgress], error)
 	ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResult, error)
 	ListPrompts(ctx context.Context, in *ListPromptsRequest, opts ...grpc.CallOption) (*ListPromptsResult, error)
 	GetPrompt(ctx context.Context, in *GetPromptRequest, opts ...grpc.CallOption) (*GetPromptResult, error)
 	ListResources(ctx context.Context, in *ListResourcesRequest, opts ...grpc.CallOption) (*ListResourcesResult, error)
 	ListResourceTemplates(ctx context.Context, in *ListResourceTemplatesRequest, opts ...grpc.CallOption) (*ListResourceTemplatesResult, error)
+	ListAllTools(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tool], error)
+	ListAllPrompts(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Prompt], error)
+	ListAllResources(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Resource], error)
+	ListAllResourceTemplates(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResourceTemplate], error)
+	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResult, error)
+	SubscribeResource(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResourceUpdatedNotification], error)
 	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResult, error)
+	SetLoggingLevel(ctx context.Context, in *SetLevelRequest, opts ...grpc.CallOption) (*SetLevelResult, error)
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LoggingMessageNotification], error)
+	Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientResponse, ServerRequest], error)
+	WatchCatalog(ctx context.Context, in *WatchCatalogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CatalogChange], error)
+	SetRoots(ctx context.Context, in *SetRootsRequest, opts ...grpc.CallOption) (*SetRootsResult, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResult, error)
+	Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResult, error)
 }
 
 type modelContextProtocolClient struct {
@@ -88,6 +116,35 @@ func (c *modelContextProtocolClient) CallMethodStream(ctx context.Context, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ModelContextProtocol_CallMethodStreamClient = grpc.BidiStreamingClient[CallToolRequest, CallToolResult]
 
+func (c *modelContextProtocolClient) CallMethodBatch(ctx context.Context, in *CallToolBatchRequest, opts ...grpc.CallOption) (*CallToolBatchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CallToolBatchResult)
+	err := c.cc.Invoke(ctx, ModelContextProtocol_CallMethodBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelContextProtocolClient) CallToolWithProgress(ctx context.Context, in *CallToolRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CallToolProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[1], ModelContextProtocol_CallToolWithProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CallToolRequest, CallToolProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_CallToolWithProgressClient = grpc.ServerStreamingClient[CallToolProgress]
+
 func (c *modelContextProtocolClient) ListTools(ctx context.Context, in *ListToolsRequest, opts ...grpc.CallOption) (*ListToolsResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListToolsResult)
@@ -138,6 +195,111 @@ func (c *modelContextProtocolClient) ListResourceTemplates(ctx context.Context,
 	return out, nil
 }
 
+func (c *modelContextProtocolClient) ListAllTools(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Tool], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[2], ModelContextProtocol_ListAllTools_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAllRequest, Tool]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllToolsClient = grpc.ServerStreamingClient[Tool]
+
+func (c *modelContextProtocolClient) ListAllPrompts(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Prompt], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[3], ModelContextProtocol_ListAllPrompts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAllRequest, Prompt]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllPromptsClient = grpc.ServerStreamingClient[Prompt]
+
+func (c *modelContextProtocolClient) ListAllResources(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Resource], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[4], ModelContextProtocol_ListAllResources_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAllRequest, Resource]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllResourcesClient = grpc.ServerStreamingClient[Resource]
+
+func (c *modelContextProtocolClient) ListAllResourceTemplates(ctx context.Context, in *ListAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResourceTemplate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[5], ModelContextProtocol_ListAllResourceTemplates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListAllRequest, ResourceTemplate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllResourceTemplatesClient = grpc.ServerStreamingClient[ResourceTemplate]
+
+func (c *modelContextProtocolClient) ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadResourceResult)
+	err := c.cc.Invoke(ctx, ModelContextProtocol_ReadResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelContextProtocolClient) SubscribeResource(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResourceUpdatedNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[6], ModelContextProtocol_SubscribeResource_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, ResourceUpdatedNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_SubscribeResourceClient = grpc.ServerStreamingClient[ResourceUpdatedNotification]
+
 func (c *modelContextProtocolClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CompleteResult)
@@ -148,6 +310,77 @@ func (c *modelContextProtocolClient) Complete(ctx context.Context, in *CompleteR
 	return out, nil
 }
 
+func (c *modelContextProtocolClient) SetLoggingLevel(ctx context.Context, in *SetLevelRequest, opts ...grpc.CallOption) (*SetLevelResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLevelResult)
+	err := c.cc.Invoke(ctx, ModelContextProtocol_SetLoggingLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelContextProtocolClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LoggingMessageNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[7], ModelContextProtocol_StreamLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamLogsRequest, LoggingMessageNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_StreamLogsClient = grpc.ServerStreamingClient[LoggingMessageNotification]
+
+func (c *modelContextProtocolClient) Session(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientResponse, ServerRequest], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[8], ModelContextProtocol_Session_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ClientResponse, ServerRequest]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_SessionClient = grpc.BidiStreamingClient[ClientResponse, ServerRequest]
+
+func (c *modelContextProtocolClient) WatchCatalog(ctx context.Context, in *WatchCatalogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CatalogChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ModelContextProtocol_ServiceDesc.Streams[9], ModelContextProtocol_WatchCatalog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCatalogRequest, CatalogChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_WatchCatalogClient = grpc.ServerStreamingClient[CatalogChange]
+
+func (c *modelContextProtocolClient) SetRoots(ctx context.Context, in *SetRootsRequest, opts ...grpc.CallOption) (*SetRootsResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRootsResult)
+	err := c.cc.Invoke(ctx, ModelContextProtocol_SetRoots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *modelContextProtocolClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PingResult)
@@ -158,6 +391,16 @@ func (c *modelContextProtocolClient) Ping(ctx context.Context, in *PingRequest,
 	return out, nil
 }
 
+func (c *modelContextProtocolClient) Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TerminateResult)
+	err := c.cc.Invoke(ctx, ModelContextProtocol_Terminate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ModelContextProtocolServer is the server API for ModelContextProtocol service.
 // All implementations should embed UnimplementedModelContextProtocolServer
 // for forward compatibility.
@@ -165,13 +408,27 @@ type ModelContextProtocolServer interface {
 	Initialize(context.Context, *InitializeRequest) (*InitializeResult, error)
 	CallMethod(context.Context, *CallToolRequest) (*CallToolResult, error)
 	CallMethodStream(grpc.BidiStreamingServer[CallToolRequest, CallToolResult]) error
+	CallMethodBatch(context.Context, *CallToolBatchRequest) (*CallToolBatchResult, error)
+	CallToolWithProgress(*CallToolRequest, grpc.ServerStreamingServer[CallToolProgress]) error
 	ListTools(context.Context, *ListToolsRequest) (*ListToolsResult, error)
 	ListPrompts(context.Context, *ListPromptsRequest) (*ListPromptsResult, error)
 	GetPrompt(context.Context, *GetPromptRequest) (*GetPromptResult, error)
 	ListResources(context.Context, *ListResourcesRequest) (*ListResourcesResult, error)
 	ListResourceTemplates(context.Context, *ListResourceTemplatesRequest) (*ListResourceTemplatesResult, error)
+	ListAllTools(*ListAllRequest, grpc.ServerStreamingServer[Tool]) error
+	ListAllPrompts(*ListAllRequest, grpc.ServerStreamingServer[Prompt]) error
+	ListAllResources(*ListAllRequest, grpc.ServerStreamingServer[Resource]) error
+	ListAllResourceTemplates(*ListAllRequest, grpc.ServerStreamingServer[ResourceTemplate]) error
+	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResult, error)
+	SubscribeResource(*SubscribeRequest, grpc.ServerStreamingServer[ResourceUpdatedNotification]) error
 	Complete(context.Context, *CompleteRequest) (*CompleteResult, error)
+	SetLoggingLevel(context.Context, *SetLevelRequest) (*SetLevelResult, error)
+	StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[LoggingMessageNotification]) error
+	Session(grpc.BidiStreamingServer[ClientResponse, ServerRequest]) error
+	WatchCatalog(*WatchCatalogRequest, grpc.ServerStreamingServer[CatalogChange]) error
+	SetRoots(context.Context, *SetRootsRequest) (*SetRootsResult, error)
 	Ping(context.Context, *PingRequest) (*PingResult, error)
+	Terminate(context.Context, *TerminateRequest) (*TerminateResult, error)
 }
 
 // UnimplementedModelContextProtocolServer should be embedded to have
@@ -190,6 +447,12 @@ func (UnimplementedModelContextProtocolServer) CallMethod(context.Context, *Call
 func (UnimplementedModelContextProtocolServer) CallMethodStream(grpc.BidiStreamingServer[CallToolRequest, CallToolResult]) error {
 	return status.Errorf(codes.Unimplemented, "method CallMethodStream not implemented")
 }
+func (UnimplementedModelContextProtocolServer) CallMethodBatch(context.Context, *CallToolBatchRequest) (*CallToolBatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CallMethodBatch not implemented")
+}
+func (UnimplementedModelContextProtocolServer) CallToolWithProgress(*CallToolRequest, grpc.ServerStreamingServer[CallToolProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method CallToolWithProgress not implemented")
+}
 func (UnimplementedModelContextProtocolServer) ListTools(context.Context, *ListToolsRequest) (*ListToolsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTools not implemented")
 }
@@ -205,12 +468,48 @@ func (UnimplementedModelContextProtocolServer) ListResources(context.Context, *L
 func (UnimplementedModelContextProtocolServer) ListResourceTemplates(context.Context, *ListResourceTemplatesRequest) (*ListResourceTemplatesResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListResourceTemplates not implemented")
 }
+func (UnimplementedModelContextProtocolServer) ListAllTools(*ListAllRequest, grpc.ServerStreamingServer[Tool]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAllTools not implemented")
+}
+func (UnimplementedModelContextProtocolServer) ListAllPrompts(*ListAllRequest, grpc.ServerStreamingServer[Prompt]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAllPrompts not implemented")
+}
+func (UnimplementedModelContextProtocolServer) ListAllResources(*ListAllRequest, grpc.ServerStreamingServer[Resource]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAllResources not implemented")
+}
+func (UnimplementedModelContextProtocolServer) ListAllResourceTemplates(*ListAllRequest, grpc.ServerStreamingServer[ResourceTemplate]) error {
+	return status.Errorf(codes.Unimplemented, "method ListAllResourceTemplates not implemented")
+}
+func (UnimplementedModelContextProtocolServer) ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadResource not implemented")
+}
+func (UnimplementedModelContextProtocolServer) SubscribeResource(*SubscribeRequest, grpc.ServerStreamingServer[ResourceUpdatedNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeResource not implemented")
+}
 func (UnimplementedModelContextProtocolServer) Complete(context.Context, *CompleteRequest) (*CompleteResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
 }
+func (UnimplementedModelContextProtocolServer) SetLoggingLevel(context.Context, *SetLevelRequest) (*SetLevelResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLoggingLevel not implemented")
+}
+func (UnimplementedModelContextProtocolServer) StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[LoggingMessageNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (UnimplementedModelContextProtocolServer) Session(grpc.BidiStreamingServer[ClientResponse, ServerRequest]) error {
+	return status.Errorf(codes.Unimplemented, "method Session not implemented")
+}
+func (UnimplementedModelContextProtocolServer) WatchCatalog(*WatchCatalogRequest, grpc.ServerStreamingServer[CatalogChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCatalog not implemented")
+}
+func (UnimplementedModelContextProtocolServer) SetRoots(context.Context, *SetRootsRequest) (*SetRootsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoots not implemented")
+}
 func (UnimplementedModelContextProtocolServer) Ping(context.Context, *PingRequest) (*PingResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedModelContextProtocolServer) Terminate(context.Context, *TerminateRequest) (*TerminateResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Terminate not implemented")
+}
 func (UnimplementedModelContextProtocolServer) testEmbeddedByValue() {}
 
 // UnsafeModelContextProtocolServer may be embedded to opt out of forward compatibility for this service.
@@ -274,6 +573,35 @@ func _ModelContextProtocol_CallMethodStream_Handler(srv interface{}, stream grpc
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ModelContextProtocol_CallMethodStreamServer = grpc.BidiStreamingServer[CallToolRequest, CallToolResult]
 
+func _ModelContextProtocol_CallMethodBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallToolBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelContextProtocolServer).CallMethodBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelContextProtocol_CallMethodBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelContextProtocolServer).CallMethodBatch(ctx, req.(*CallToolBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelContextProtocol_CallToolWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CallToolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).CallToolWithProgress(m, &grpc.GenericServerStream[CallToolRequest, CallToolProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_CallToolWithProgressServer = grpc.ServerStreamingServer[CallToolProgress]
+
 func _ModelContextProtocol_ListTools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListToolsRequest)
 	if err := dec(in); err != nil {
@@ -364,6 +692,79 @@ func _ModelContextProtocol_ListResourceTemplates_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ModelContextProtocol_ListAllTools_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).ListAllTools(m, &grpc.GenericServerStream[ListAllRequest, Tool]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllToolsServer = grpc.ServerStreamingServer[Tool]
+
+func _ModelContextProtocol_ListAllPrompts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).ListAllPrompts(m, &grpc.GenericServerStream[ListAllRequest, Prompt]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllPromptsServer = grpc.ServerStreamingServer[Prompt]
+
+func _ModelContextProtocol_ListAllResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).ListAllResources(m, &grpc.GenericServerStream[ListAllRequest, Resource]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllResourcesServer = grpc.ServerStreamingServer[Resource]
+
+func _ModelContextProtocol_ListAllResourceTemplates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).ListAllResourceTemplates(m, &grpc.GenericServerStream[ListAllRequest, ResourceTemplate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_ListAllResourceTemplatesServer = grpc.ServerStreamingServer[ResourceTemplate]
+
+func _ModelContextProtocol_ReadResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelContextProtocolServer).ReadResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelContextProtocol_ReadResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelContextProtocolServer).ReadResource(ctx, req.(*ReadResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelContextProtocol_SubscribeResource_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).SubscribeResource(m, &grpc.GenericServerStream[SubscribeRequest, ResourceUpdatedNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_SubscribeResourceServer = grpc.ServerStreamingServer[ResourceUpdatedNotification]
+
 func _ModelContextProtocol_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CompleteRequest)
 	if err := dec(in); err != nil {
@@ -382,6 +783,71 @@ func _ModelContextProtocol_Complete_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ModelContextProtocol_SetLoggingLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelContextProtocolServer).SetLoggingLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelContextProtocol_SetLoggingLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelContextProtocolServer).SetLoggingLevel(ctx, req.(*SetLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelContextProtocol_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).StreamLogs(m, &grpc.GenericServerStream[StreamLogsRequest, LoggingMessageNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_StreamLogsServer = grpc.ServerStreamingServer[LoggingMessageNotification]
+
+func _ModelContextProtocol_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ModelContextProtocolServer).Session(&grpc.GenericServerStream[ClientResponse, ServerRequest]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_SessionServer = grpc.BidiStreamingServer[ClientResponse, ServerRequest]
+
+func _ModelContextProtocol_WatchCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCatalogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelContextProtocolServer).WatchCatalog(m, &grpc.GenericServerStream[WatchCatalogRequest, CatalogChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ModelContextProtocol_WatchCatalogServer = grpc.ServerStreamingServer[CatalogChange]
+
+func _ModelContextProtocol_SetRoots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRootsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelContextProtocolServer).SetRoots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelContextProtocol_SetRoots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelContextProtocolServer).SetRoots(ctx, req.(*SetRootsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ModelContextProtocol_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PingRequest)
 	if err := dec(in); err != nil {
@@ -400,6 +866,24 @@ func _ModelContextProtocol_Ping_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ModelContextProtocol_Terminate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelContextProtocolServer).Terminate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModelContextProtocol_Terminate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelContextProtocolServer).Terminate(ctx, req.(*TerminateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ModelContextProtocol_ServiceDesc is the grpc.ServiceDesc for ModelContextProtocol service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -415,6 +899,10 @@ var ModelContextProtocol_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CallMethod",
 			Handler:    _ModelContextProtocol_CallMethod_Handler,
 		},
+		{
+			MethodName: "CallMethodBatch",
+			Handler:    _ModelContextProtocol_CallMethodBatch_Handler,
+		},
 		{
 			MethodName: "ListTools",
 			Handler:    _ModelContextProtocol_ListTools_Handler,
@@ -435,14 +923,30 @@ var ModelContextProtocol_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListResourceTemplates",
 			Handler:    _ModelContextProtocol_ListResourceTemplates_Handler,
 		},
+		{
+			MethodName: "ReadResource",
+			Handler:    _ModelContextProtocol_ReadResource_Handler,
+		},
 		{
 			MethodName: "Complete",
 			Handler:    _ModelContextProtocol_Complete_Handler,
 		},
+		{
+			MethodName: "SetLoggingLevel",
+			Handler:    _ModelContextProtocol_SetLoggingLevel_Handler,
+		},
+		{
+			MethodName: "SetRoots",
+			Handler:    _ModelContextProtocol_SetRoots_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _ModelContextProtocol_Ping_Handler,
 		},
+		{
+			MethodName: "Terminate",
+			Handler:    _ModelContextProtocol_Terminate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -451,6 +955,52 @@ var ModelContextProtocol_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "CallToolWithProgress",
+			Handler:       _ModelContextProtocol_CallToolWithProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAllTools",
+			Handler:       _ModelContextProtocol_ListAllTools_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAllPrompts",
+			Handler:       _ModelContextProtocol_ListAllPrompts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAllResources",
+			Handler:       _ModelContextProtocol_ListAllResources_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAllResourceTemplates",
+			Handler:       _ModelContextProtocol_ListAllResourceTemplates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeResource",
+			Handler:       _ModelContextProtocol_SubscribeResource_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _ModelContextProtocol_StreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Session",
+			Handler:       _ModelContextProtocol_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchCatalog",
+			Handler:       _ModelContextProtocol_WatchCatalog_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "mcp.proto",
 }